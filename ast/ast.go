@@ -9,6 +9,8 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	// Pos is the position of the first token of the node.
+	Pos() token.Position
 }
 
 type Statement interface {
@@ -35,6 +37,13 @@ func (p *Program) String() string {
 	return out.String()
 }
 
+func (p *Program) Pos() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return token.Position{}
+}
+
 func (p *Program) TokenLiteral() string {
 	if len(p.Statements) > 0 {
 		return p.Statements[0].TokenLiteral()
@@ -51,6 +60,7 @@ type LetStatement struct {
 
 func (stmt *LetStatement) statementNode()       {}
 func (stmt *LetStatement) TokenLiteral() string { return stmt.Token.Literal }
+func (stmt *LetStatement) Pos() token.Position  { return stmt.Token.Pos }
 func (stmt *LetStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(stmt.TokenLiteral() + " ")
@@ -71,6 +81,7 @@ type IdentifierExpression struct {
 
 func (expr *IdentifierExpression) expressionNode()      {}
 func (expr *IdentifierExpression) TokenLiteral() string { return expr.Token.Literal }
+func (expr *IdentifierExpression) Pos() token.Position  { return expr.Token.Pos }
 func (expr *IdentifierExpression) String() string       { return expr.Value }
 
 type ReturnStatement struct {
@@ -80,6 +91,7 @@ type ReturnStatement struct {
 
 func (stmt *ReturnStatement) statementNode()       {}
 func (stmt *ReturnStatement) TokenLiteral() string { return stmt.Token.Literal }
+func (stmt *ReturnStatement) Pos() token.Position  { return stmt.Token.Pos }
 func (stmt *ReturnStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(stmt.TokenLiteral() + " ")
@@ -98,6 +110,7 @@ type ExpressionStatement struct {
 
 func (stmt *ExpressionStatement) statementNode()       {}
 func (stmt *ExpressionStatement) TokenLiteral() string { return stmt.Token.Literal }
+func (stmt *ExpressionStatement) Pos() token.Position  { return stmt.Token.Pos }
 
 func (stmt *ExpressionStatement) String() string {
 	if stmt.Expression != nil {
@@ -113,6 +126,7 @@ type IntegerLiteral struct {
 
 func (expr *IntegerLiteral) expressionNode()      {}
 func (expr *IntegerLiteral) TokenLiteral() string { return expr.Token.Literal }
+func (expr *IntegerLiteral) Pos() token.Position  { return expr.Token.Pos }
 func (expr *IntegerLiteral) String() string       { return expr.Token.Literal }
 
 type UnaryExpression struct {
@@ -123,6 +137,7 @@ type UnaryExpression struct {
 
 func (expr *UnaryExpression) expressionNode()      {}
 func (expr *UnaryExpression) TokenLiteral() string { return expr.Token.Literal }
+func (expr *UnaryExpression) Pos() token.Position  { return expr.Token.Pos }
 func (expr *UnaryExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -142,6 +157,7 @@ type BinaryExpression struct {
 
 func (expr *BinaryExpression) expressionNode()      {}
 func (expr *BinaryExpression) TokenLiteral() string { return expr.Token.Literal }
+func (expr *BinaryExpression) Pos() token.Position  { return expr.Left.Pos() }
 func (expr *BinaryExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -160,6 +176,7 @@ type Boolean struct {
 
 func (expr *Boolean) expressionNode()      {}
 func (expr *Boolean) TokenLiteral() string { return expr.Token.Literal }
+func (expr *Boolean) Pos() token.Position  { return expr.Token.Pos }
 func (expr *Boolean) String() string       { return expr.Token.Literal }
 
 type IfExpression struct {
@@ -171,6 +188,7 @@ type IfExpression struct {
 
 func (expr *IfExpression) expressionNode()      {}
 func (expr *IfExpression) TokenLiteral() string { return expr.Token.Literal }
+func (expr *IfExpression) Pos() token.Position  { return expr.Token.Pos }
 func (expr *IfExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("if")
@@ -192,6 +210,7 @@ type BlockStatement struct {
 
 func (stmt *BlockStatement) statementNode()       {}
 func (stmt *BlockStatement) TokenLiteral() string { return stmt.Token.Literal }
+func (stmt *BlockStatement) Pos() token.Position  { return stmt.Token.Pos }
 func (stmt *BlockStatement) String() string {
 	var out bytes.Buffer
 
@@ -203,13 +222,21 @@ func (stmt *BlockStatement) String() string {
 }
 
 type FunctionLiteral struct {
-	Token      token.Token
+	Token token.Token
+	// Name is given as `fn name(...)` or taken from the `let` statement
+	// the literal is bound by; it is empty for anonymous functions.
+	Name string
+	// SelfBound reports whether Name was given as `fn name(...)`. Only
+	// then is it bound inside the function's body; a name taken from
+	// `let` just describes the function.
+	SelfBound  bool
 	Parameters []*IdentifierExpression
 	Body       *BlockStatement
 }
 
 func (expr *FunctionLiteral) expressionNode()      {}
 func (expr *FunctionLiteral) TokenLiteral() string { return expr.Token.Literal }
+func (expr *FunctionLiteral) Pos() token.Position  { return expr.Token.Pos }
 func (expr *FunctionLiteral) String() string {
 	var out bytes.Buffer
 
@@ -219,6 +246,9 @@ func (expr *FunctionLiteral) String() string {
 	}
 
 	out.WriteString("fn")
+	if expr.SelfBound {
+		out.WriteString(" " + expr.Name)
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(")")
@@ -235,6 +265,7 @@ type CallExpression struct {
 
 func (expr *CallExpression) expressionNode()      {}
 func (expr *CallExpression) TokenLiteral() string { return expr.Token.Literal }
+func (expr *CallExpression) Pos() token.Position  { return expr.Function.Pos() }
 func (expr *CallExpression) String() string {
 	var out bytes.Buffer
 
@@ -258,6 +289,7 @@ type StringLiteral struct {
 
 func (expr *StringLiteral) expressionNode()      {}
 func (expr *StringLiteral) TokenLiteral() string { return expr.Token.Literal }
+func (expr *StringLiteral) Pos() token.Position  { return expr.Token.Pos }
 func (expr *StringLiteral) String() string       { return expr.Value }
 
 type ArrayLiteral struct {
@@ -267,6 +299,7 @@ type ArrayLiteral struct {
 
 func (expr *ArrayLiteral) expressionNode()      {}
 func (expr *ArrayLiteral) TokenLiteral() string { return expr.Token.Literal }
+func (expr *ArrayLiteral) Pos() token.Position  { return expr.Token.Pos }
 func (expr *ArrayLiteral) String() string {
 	var out bytes.Buffer
 
@@ -290,6 +323,7 @@ type IndexExpression struct {
 
 func (expr *IndexExpression) expressionNode()      {}
 func (expr *IndexExpression) TokenLiteral() string { return expr.Token.Literal }
+func (expr *IndexExpression) Pos() token.Position  { return expr.Left.Pos() }
 func (expr *IndexExpression) String() string {
 	var out bytes.Buffer
 
@@ -302,19 +336,26 @@ func (expr *IndexExpression) String() string {
 	return out.String()
 }
 
+// HashPair is a single key/value entry of a HashLiteral.
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
 type HashLiteral struct {
 	Token token.Token
-	Pairs map[Expression]Expression
+	Pairs []HashPair
 }
 
 func (expr *HashLiteral) expressionNode()      {}
 func (expr *HashLiteral) TokenLiteral() string { return expr.Token.Literal }
+func (expr *HashLiteral) Pos() token.Position  { return expr.Token.Pos }
 func (expr *HashLiteral) String() string {
 	var out bytes.Buffer
 
 	pairs := []string{}
-	for key, value := range expr.Pairs {
-		pairs = append(pairs, key.String()+": "+value.String())
+	for _, pair := range expr.Pairs {
+		pairs = append(pairs, pair.Key.String()+": "+pair.Value.String())
 	}
 
 	out.WriteString("{")
@@ -332,6 +373,7 @@ type MacroLiteral struct {
 
 func (expr *MacroLiteral) expressionNode()      {}
 func (expr *MacroLiteral) TokenLiteral() string { return expr.Token.Literal }
+func (expr *MacroLiteral) Pos() token.Position  { return expr.Token.Pos }
 func (expr *MacroLiteral) String() string {
 	var out bytes.Buffer
 
@@ -61,11 +61,9 @@ func Modify(node Node, modifier ModifierFunc) Node {
 		}
 
 	case *HashLiteral:
-		newPairs := make(map[Expression]Expression, len(node.Pairs))
-		for k, v := range node.Pairs {
-			newKey := Modify(k, modifier).(Expression)
-			newValue := Modify(v, modifier).(Expression)
-			newPairs[newKey] = newValue
+		for i := range node.Pairs {
+			node.Pairs[i].Key = Modify(node.Pairs[i].Key, modifier).(Expression)
+			node.Pairs[i].Value = Modify(node.Pairs[i].Value, modifier).(Expression)
 		}
 	}
 
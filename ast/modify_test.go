@@ -130,20 +130,20 @@ func TestModify(t *testing.T) {
 	}
 
 	hashLiteral := &ast.HashLiteral{
-		Pairs: map[ast.Expression]ast.Expression{
-			one(): one(),
-			one(): one(),
+		Pairs: []ast.HashPair{
+			{Key: one(), Value: one()},
+			{Key: one(), Value: one()},
 		},
 	}
 
 	ast.Modify(hashLiteral, turnOneIntoTwo)
 
-	for key, val := range hashLiteral.Pairs {
-		key, _ := key.(*ast.IntegerLiteral)
+	for _, pair := range hashLiteral.Pairs {
+		key, _ := pair.Key.(*ast.IntegerLiteral)
 		if key.Value != 2 {
 			t.Errorf("value is not %d, got=%d", 2, key.Value)
 		}
-		val, _ := val.(*ast.IntegerLiteral)
+		val, _ := pair.Value.(*ast.IntegerLiteral)
 		if val.Value != 2 {
 			t.Errorf("value is not %d, got=%d", 2, val.Value)
 		}
@@ -1,120 +1,125 @@
 package evaluator
 
 import (
-	"fmt"
 	"monkeylang/object"
 )
 
-var builtins = map[string]*object.Builtin{
-	"len": {
-		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=%d",
-					len(args), 1)
-			}
-
-			switch arg := args[0].(type) {
-			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
-			case *object.Array:
-				return &object.Integer{Value: int64(len(arg.Elements))}
-
-			default:
-				return newError("argument to `len` not supported, got %s",
-					args[0].Type())
-			}
-		},
-	},
-
-	"first": {
-		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-			if args[0].Type() != object.ARRAY_OBJ {
-				return newError("argument to `first` must be ARRAY, got %s", args[0].Type())
-			}
-			arr := args[0].(*object.Array)
-			if len(arr.Elements) > 0 {
-				return arr.Elements[0]
-			}
-			return NULL
+var modules = map[string]*object.Module{
+	"math": mathModule,
+}
+
+func (in *Interpreter) coreBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"len": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=%d",
+						len(args), 1)
+				}
+
+				switch arg := args[0].(type) {
+				case *object.String:
+					return &object.Integer{Value: int64(len(arg.Value))}
+				case *object.Array:
+					return &object.Integer{Value: int64(len(arg.Elements))}
+				case *object.Hash:
+					return &object.Integer{Value: int64(arg.Len())}
+
+				default:
+					return newError("argument to `len` not supported, got %s",
+						args[0].Type())
+				}
+			},
 		},
-	},
-
-	"last": {
-		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-
-			arr, ok := args[0].(*object.Array)
-			if !ok {
-				return newError("argument to `last` must be ARRAY, got %s", args[0].Type())
-			}
-
-			length := len(arr.Elements)
-			if length > 0 {
-				return arr.Elements[length-1]
-			}
-
-			return NULL
+
+		"first": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+				if args[0].Type() != object.ARRAY_OBJ {
+					return newError("argument to `first` must be ARRAY, got %s", args[0].Type())
+				}
+				arr := args[0].(*object.Array)
+				if len(arr.Elements) > 0 {
+					return arr.Elements[0]
+				}
+				return NULL
+			},
 		},
-	},
-
-	"rest": {
-		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1",
-					len(args))
-			}
-
-			arr, ok := args[0].(*object.Array)
-			if !ok {
-				return newError("argument to `rest` must be ARRAY, got %s", args[0].Type())
-			}
-
-			length := len(arr.Elements)
-			if length > 0 {
-				newElements := make([]object.Object, length-1)
-				copy(newElements, arr.Elements[1:])
-				return &object.Array{Elements: newElements}
-			}
 
-			return NULL
+		"last": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `last` must be ARRAY, got %s", args[0].Type())
+				}
+
+				length := len(arr.Elements)
+				if length > 0 {
+					return arr.Elements[length-1]
+				}
+
+				return NULL
+			},
 		},
-	},
-
-	"push": {
-		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2",
-					len(args))
-			}
-
-			arr, ok := args[0].(*object.Array)
-			if !ok {
-				return newError("argument to `push` must be ARRAY, got %s", args[0].Type())
-			}
-
-			length := len(arr.Elements)
-			newElements := make([]object.Object, length+1)
-			copy(newElements, arr.Elements)
-			newElements[length] = args[1]
-
-			return &object.Array{Elements: newElements}
+
+		"rest": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `rest` must be ARRAY, got %s", args[0].Type())
+				}
+
+				length := len(arr.Elements)
+				if length > 0 {
+					if err := in.reserve(1, arraySize(length-1)); err != nil {
+						return err
+					}
+
+					newElements := make([]object.Object, length-1)
+					copy(newElements, arr.Elements[1:])
+					return &object.Array{Elements: newElements}
+				}
+
+				return NULL
+			},
 		},
-	},
 
-	"puts": {
-		Fn: func(args ...object.Object) object.Object {
-			for _, arg := range args {
-				fmt.Println(arg.Inspect())
-			}
+		"push": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `push` must be ARRAY, got %s", args[0].Type())
+				}
+
+				length := len(arr.Elements)
+				if err := in.reserve(1, arraySize(length+1)); err != nil {
+					return err
+				}
 
-			return NULL
+				newElements := make([]object.Object, length+1)
+				copy(newElements, arr.Elements)
+				newElements[length] = args[1]
+
+				return &object.Array{Elements: newElements}
+			},
 		},
-	},
+	}
 }
@@ -0,0 +1,534 @@
+package evaluator
+
+import (
+	"math"
+	"monkeylang/object"
+	"slices"
+	"strings"
+)
+
+func (in *Interpreter) arrayBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"map": {
+			Fn: func(args ...object.Object) object.Object {
+				arr, fn, err := arrayAndCallbackArgs("map", args)
+				if err != nil {
+					return err
+				}
+				if err := in.reserve(1, arraySize(len(arr.Elements))); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, len(arr.Elements))
+				for i, el := range arr.Elements {
+					result := in.applyFunction(fn, []object.Object{el})
+					if isError(result) {
+						return result
+					}
+					elements[i] = result
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"filter": {
+			Fn: func(args ...object.Object) object.Object {
+				arr, fn, err := arrayAndCallbackArgs("filter", args)
+				if err != nil {
+					return err
+				}
+
+				elements := []object.Object{}
+				for _, el := range arr.Elements {
+					result := in.applyFunction(fn, []object.Object{el})
+					if isError(result) {
+						return result
+					}
+					if isTruthy(result) {
+						elements = append(elements, el)
+					}
+				}
+
+				// The result is no longer than arr, so it is counted once
+				// it is built.
+				return in.charge(&object.Array{Elements: elements})
+			},
+		},
+
+		"reduce": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 && len(args) != 3 {
+					return newError("wrong number of arguments. got=%d, want=2 or 3",
+						len(args))
+				}
+
+				arr, fn, err := arrayAndCallbackArgs("reduce", args[:2])
+				if err != nil {
+					return err
+				}
+
+				elements := arr.Elements
+				var acc object.Object
+				if len(args) == 3 {
+					acc = args[2]
+				} else {
+					if len(elements) == 0 {
+						return newError("`reduce` of empty ARRAY with no initial value")
+					}
+					acc, elements = elements[0], elements[1:]
+				}
+
+				for _, el := range elements {
+					acc = in.applyFunction(fn, []object.Object{acc, el})
+					if isError(acc) {
+						return acc
+					}
+				}
+
+				return acc
+			},
+		},
+
+		"find": {
+			Fn: func(args ...object.Object) object.Object {
+				arr, fn, err := arrayAndCallbackArgs("find", args)
+				if err != nil {
+					return err
+				}
+
+				for _, el := range arr.Elements {
+					result := in.applyFunction(fn, []object.Object{el})
+					if isError(result) {
+						return result
+					}
+					if isTruthy(result) {
+						return el
+					}
+				}
+
+				return NULL
+			},
+		},
+
+		"any": {
+			Fn: func(args ...object.Object) object.Object {
+				return in.evalPredicateBuiltin("any", args, true)
+			},
+		},
+
+		"all": {
+			Fn: func(args ...object.Object) object.Object {
+				return in.evalPredicateBuiltin("all", args, false)
+			},
+		},
+
+		"sort": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `sort` must be ARRAY, got %s", args[0].Type())
+				}
+
+				compare := compareObjects
+				if len(args) == 2 {
+					if !isCallable(args[1]) {
+						return newError("argument to `sort` must be FUNCTION, got %s",
+							args[1].Type())
+					}
+					compare = func(a, b object.Object) (int, object.Object) {
+						return in.callComparator(args[1], a, b)
+					}
+				}
+
+				if err := in.reserve(1, arraySize(len(arr.Elements))); err != nil {
+					return err
+				}
+
+				var sortErr object.Object
+				elements := slices.Clone(arr.Elements)
+				slices.SortStableFunc(elements, func(a, b object.Object) int {
+					if sortErr != nil {
+						return 0
+					}
+					result, err := compare(a, b)
+					if err != nil {
+						sortErr = err
+					}
+					return result
+				})
+				if sortErr != nil {
+					return sortErr
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"reverse": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `reverse` must be ARRAY, got %s", args[0].Type())
+				}
+				if err := in.reserve(1, arraySize(len(arr.Elements))); err != nil {
+					return err
+				}
+
+				elements := slices.Clone(arr.Elements)
+				slices.Reverse(elements)
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"concat": {
+			Fn: func(args ...object.Object) object.Object {
+				length := 0
+				for _, arg := range args {
+					arr, ok := arg.(*object.Array)
+					if !ok {
+						return newError("argument to `concat` must be ARRAY, got %s", arg.Type())
+					}
+					length = addSize(length, len(arr.Elements))
+				}
+				if err := in.reserve(1, arraySize(length)); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, 0, length)
+				for _, arg := range args {
+					elements = append(elements, arg.(*object.Array).Elements...)
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"flatten": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `flatten` must be ARRAY, got %s", args[0].Type())
+				}
+
+				depth := int64(1)
+				if len(args) == 2 {
+					integer, ok := args[1].(*object.Integer)
+					if !ok {
+						return newError("argument to `flatten` must be INTEGER, got %s",
+							args[1].Type())
+					}
+					depth = integer.Value
+				}
+
+				length := flattenLen(arr.Elements, depth)
+				if err := in.reserve(1, arraySize(length)); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, 0, length)
+				return &object.Array{Elements: flatten(elements, arr.Elements, depth)}
+			},
+		},
+
+		"zip": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) < 1 {
+					return newError("wrong number of arguments. got=%d, want>=1",
+						len(args))
+				}
+
+				arrays := make([]*object.Array, len(args))
+				length := -1
+				for i, arg := range args {
+					arr, ok := arg.(*object.Array)
+					if !ok {
+						return newError("argument to `zip` must be ARRAY, got %s", arg.Type())
+					}
+					arrays[i] = arr
+					if length < 0 || len(arr.Elements) < length {
+						length = len(arr.Elements)
+					}
+				}
+
+				tupleSize := arraySize(len(arrays))
+				if err := in.reserve(length+1, addSize(arraySize(length), mulSize(length, tupleSize))); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, length)
+				for i := range length {
+					tuple := make([]object.Object, len(arrays))
+					for j, arr := range arrays {
+						tuple[j] = arr.Elements[i]
+					}
+					elements[i] = &object.Array{Elements: tuple}
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"range": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) < 1 || len(args) > 3 {
+					return newError("wrong number of arguments. got=%d, want=1 to 3",
+						len(args))
+				}
+
+				bounds := make([]int64, len(args))
+				for i, arg := range args {
+					integer, ok := arg.(*object.Integer)
+					if !ok {
+						return newError("argument to `range` must be INTEGER, got %s", arg.Type())
+					}
+					bounds[i] = integer.Value
+				}
+
+				start, end, step := int64(0), bounds[0], int64(1)
+				if len(bounds) > 1 {
+					start, end = bounds[0], bounds[1]
+				}
+				if len(bounds) > 2 {
+					step = bounds[2]
+				}
+				if step == 0 {
+					return newError("`range` step must not be 0")
+				}
+
+				count, ok := rangeLen(start, end, step)
+				if !ok {
+					return newError("`range` of %d to %d by %d is too long", start, end, step)
+				}
+				if err := in.reserve(count+1, addSize(arraySize(count), mulSize(count, objectSize))); err != nil {
+					return err
+				}
+
+				elements := []object.Object{}
+				for i := range count {
+					if i%1024 == 0 {
+						if err := in.ctx.Err(); err != nil {
+							return contextError(err)
+						}
+					}
+					// i*step may overflow, but the sum wraps back to the
+					// element, which is always in range.
+					elements = append(elements, &object.Integer{Value: start + int64(i)*step})
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"join": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `join` must be ARRAY, got %s", args[0].Type())
+				}
+
+				separator := ""
+				if len(args) == 2 {
+					str, ok := args[1].(*object.String)
+					if !ok {
+						return newError("argument to `join` must be STRING, got %s", args[1].Type())
+					}
+					separator = str.Value
+				}
+
+				length := mulSize(len(separator), len(arr.Elements))
+				seen := map[object.Object]int{}
+				for _, el := range arr.Elements {
+					length = addSize(length, inspectLen(el, seen))
+				}
+				if err := in.reserve(1, stringSize(length)); err != nil {
+					return err
+				}
+
+				parts := make([]string, len(arr.Elements))
+				for i, el := range arr.Elements {
+					parts[i] = el.Inspect()
+				}
+
+				return &object.String{Value: strings.Join(parts, separator)}
+			},
+		},
+	}
+}
+
+func isCallable(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Function, *object.Builtin:
+		return true
+	default:
+		return false
+	}
+}
+
+// arrayAndCallbackArgs validates the (array, function) arguments shared by
+// the higher-order builtins.
+func arrayAndCallbackArgs(
+	name string,
+	args []object.Object,
+) (*object.Array, object.Object, *object.Error) {
+	if len(args) != 2 {
+		return nil, nil, newError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
+
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, nil, newError("argument to `%s` must be ARRAY, got %s",
+			name, args[0].Type())
+	}
+
+	if !isCallable(args[1]) {
+		return nil, nil, newError("argument to `%s` must be FUNCTION, got %s",
+			name, args[1].Type())
+	}
+
+	return arr, args[1], nil
+}
+
+// evalPredicateBuiltin implements `any` and `all`: it stops at the first
+// element whose truthiness equals stopOn. Without a callback the elements
+// themselves are tested.
+func (in *Interpreter) evalPredicateBuiltin(name string, args []object.Object, stopOn bool) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=1 or 2",
+			len(args))
+	}
+
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return newError("argument to `%s` must be ARRAY, got %s", name, args[0].Type())
+	}
+	if len(args) == 2 && !isCallable(args[1]) {
+		return newError("argument to `%s` must be FUNCTION, got %s", name, args[1].Type())
+	}
+
+	for _, el := range arr.Elements {
+		result := el
+		if len(args) == 2 {
+			result = in.applyFunction(args[1], []object.Object{el})
+			if isError(result) {
+				return result
+			}
+		}
+
+		if isTruthy(result) == stopOn {
+			return nativeBoolToBooleanObject(stopOn)
+		}
+	}
+
+	return nativeBoolToBooleanObject(!stopOn)
+}
+
+func compareObjects(a, b object.Object) (int, object.Object) {
+	comparable, ok := a.(object.Comparable)
+	if !ok {
+		return 0, newError("unknown operator: %s < %s", a.Type(), b.Type())
+	}
+
+	result, ok := comparable.Compare(b)
+	if !ok {
+		return 0, newError("unknown operator: %s < %s", a.Type(), b.Type())
+	}
+
+	return result, nil
+}
+
+func (in *Interpreter) callComparator(fn, a, b object.Object) (int, object.Object) {
+	result := in.applyFunction(fn, []object.Object{a, b})
+	if isError(result) {
+		return 0, result
+	}
+
+	integer, ok := result.(*object.Integer)
+	if !ok {
+		return 0, newError("`sort` comparator must return INTEGER, got %s", result.Type())
+	}
+
+	switch {
+	case integer.Value < 0:
+		return -1, nil
+	case integer.Value > 0:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// rangeLen returns the number of elements `range` produces from start to
+// end by step, reporting false when it is more than a slice can hold. The
+// difference of the bounds is taken in uint64 so that it cannot overflow.
+func rangeLen(start, end, step int64) (int, bool) {
+	var span, stride uint64
+	switch {
+	case step > 0 && start < end:
+		span, stride = uint64(end)-uint64(start), uint64(step)
+	case step < 0 && start > end:
+		span, stride = uint64(start)-uint64(end), -uint64(step)
+	default:
+		return 0, true
+	}
+
+	count := (span-1)/stride + 1
+	if count > math.MaxInt {
+		return 0, false
+	}
+
+	return int(count), true
+}
+
+// flatten appends elements to result, replacing arrays nested up to depth
+// levels deep by their elements.
+func flatten(result, elements []object.Object, depth int64) []object.Object {
+	for _, el := range elements {
+		arr, ok := el.(*object.Array)
+		if ok && depth > 0 {
+			result = flatten(result, arr.Elements, depth-1)
+		} else {
+			result = append(result, el)
+		}
+	}
+
+	return result
+}
+
+// flattenLen returns the length of the array flatten builds, without
+// building it.
+func flattenLen(elements []object.Object, depth int64) int {
+	length := 0
+	for _, el := range elements {
+		arr, ok := el.(*object.Array)
+		if ok && depth > 0 {
+			length = addSize(length, flattenLen(arr.Elements, depth-1))
+		} else {
+			length = addSize(length, 1)
+		}
+	}
+
+	return length
+}
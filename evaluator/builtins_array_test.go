@@ -0,0 +1,76 @@
+package evaluator_test
+
+import "testing"
+
+func TestArrayBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`map([1, 2, 3], fn(x) { x * 2 })`, `[2, 4, 6]`},
+		{`map([], fn(x) { x })`, `[]`},
+		{`map(["a"], len)`, `[1]`},
+		{`filter([1, 2, 3, 4], fn(x) { x > 2 })`, `[3, 4]`},
+		{`reduce([1, 2, 3], fn(acc, x) { acc + x })`, `6`},
+		{`reduce([1, 2, 3], fn(acc, x) { acc + x }, 10)`, `16`},
+		{`reduce([], fn(acc, x) { acc + x }, 0)`, `0`},
+		{`find([1, 2, 3], fn(x) { x > 1 })`, `2`},
+		{`find([1, 2, 3], fn(x) { x > 5 })`, `if (false) { 1 }`},
+		{`any([1, 2, 3], fn(x) { x > 2 })`, `true`},
+		{`any([1, 2, 3], fn(x) { x > 3 })`, `false`},
+		{`any([])`, `false`},
+		{`all([1, 2, 3], fn(x) { x > 0 })`, `true`},
+		{`all([true, false])`, `false`},
+		{`all([])`, `true`},
+		{`sort([3, 1, 2])`, `[1, 2, 3]`},
+		{`sort(["b", "c", "a"])`, `["a", "b", "c"]`},
+		{`sort([[2], [1, 5], [1]])`, `[[1], [1, 5], [2]]`},
+		{`sort([3, 1, 2], fn(a, b) { b - a })`, `[3, 2, 1]`},
+		{`let a = [2, 1]; sort(a); a`, `[2, 1]`},
+		{`reverse([1, 2, 3])`, `[3, 2, 1]`},
+		{`concat([1], [2, 3], [])`, `[1, 2, 3]`},
+		{`concat()`, `[]`},
+		{`flatten([1, [2, [3]]])`, `[1, 2, [3]]`},
+		{`flatten([1, [2, [3]]], 2)`, `[1, 2, 3]`},
+		{`zip([1, 2, 3], ["a", "b"])`, `[[1, "a"], [2, "b"]]`},
+		{`range(3)`, `[0, 1, 2]`},
+		{`range(1, 4)`, `[1, 2, 3]`},
+		{`range(5, 0, -2)`, `[5, 3, 1]`},
+		{`range(0)`, `[]`},
+		{`range(3, 1)`, `[]`},
+		{`range(9223372036854775800, 9223372036854775807, 10)`, `[9223372036854775800]`},
+		{`range(-9223372036854775800, -9223372036854775807, -10)`, `[-9223372036854775800]`},
+		{`range(0, 9223372036854775807, 4611686018427387904)`, `[0, 4611686018427387904]`},
+		{`join([1, "a", true], ", ")`, `"1, a, true"`},
+		{`join(["a", "b"])`, `"ab"`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestArrayBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`map([1])`, "wrong number of arguments. got=1, want=2"},
+		{`map(1, fn(x) { x })`, "argument to `map` must be ARRAY, got INTEGER"},
+		{`filter([1], 1)`, "argument to `filter` must be FUNCTION, got INTEGER"},
+		{`map([1, true], fn(x) { -x })`, "unknown operator: -BOOLEAN"},
+		{`reduce([], fn(a, b) { a })`, "`reduce` of empty ARRAY with no initial value"},
+		{`sort([1, "a"])`, "unknown operator: STRING < INTEGER"},
+		{`sort([1, 2], fn(a, b) { true })`, "`sort` comparator must return INTEGER, got BOOLEAN"},
+		{`concat([1], 2)`, "argument to `concat` must be ARRAY, got INTEGER"},
+		{`range("a")`, "argument to `range` must be INTEGER, got STRING"},
+		{`range(0, 5, 0)`, "`range` step must not be 0"},
+		{`range(-9223372036854775807 - 1, 9223372036854775807)`,
+			"`range` of -9223372036854775808 to 9223372036854775807 by 1 is too long"},
+		{`join([1], 1)`, "argument to `join` must be STRING, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
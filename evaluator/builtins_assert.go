@@ -0,0 +1,98 @@
+package evaluator
+
+import (
+	"monkeylang/object"
+)
+
+func (in *Interpreter) assertBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"assert": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				if isTruthy(args[0]) {
+					return NULL
+				}
+
+				if len(args) == 2 {
+					return newError("assertion failed: %s", args[1].Inspect())
+				}
+
+				return newError("assertion failed")
+			},
+		},
+
+		"assert_eq": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				actual, expected := args[0], args[1]
+				if object.Equal(actual, expected) {
+					return NULL
+				}
+
+				err := newError("assertion failed: expected=%s, actual=%s",
+					expected.Inspect(), actual.Inspect())
+				err.Expected = expected.Inspect()
+				err.Actual = actual.Inspect()
+
+				return err
+			},
+		},
+
+		"assert_error": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				if !isCallable(args[0]) {
+					return newError("argument to `assert_error` must be FUNCTION, got %s",
+						args[0].Type())
+				}
+
+				result := in.applyFunction(args[0], []object.Object{})
+				switch result := result.(type) {
+				case *object.Error:
+					// Limits and cancellation stop the whole evaluation,
+					// not just the function under test.
+					if result.Kind != object.RUNTIME_ERROR {
+						return result
+					}
+					return &object.String{Value: result.Message}
+				case *object.Exit:
+					return result
+				}
+
+				err := newError("assertion failed: expected an error, got %s",
+					result.Inspect())
+				err.Expected = "ERROR"
+				err.Actual = result.Inspect()
+
+				return err
+			},
+		},
+
+		"fail": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) > 1 {
+					return newError("wrong number of arguments. got=%d, want=0 or 1",
+						len(args))
+				}
+
+				if len(args) == 1 {
+					return newError("failed: %s", args[0].Inspect())
+				}
+
+				return newError("failed")
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package evaluator_test
+
+import (
+	"monkeylang/object"
+	"monkeylang/token"
+	"testing"
+)
+
+func TestAssertBuiltinsPass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`assert(true)`, `if (false) { 1 }`},
+		{`assert(1 < 2, "math")`, `if (false) { 1 }`},
+		{`assert_eq([1, {"a": [2]}], [1, {"a": [2]}])`, `if (false) { 1 }`},
+		{`assert_eq({"a": 1, "b": 2}, {"b": 2, "a": 1})`, `if (false) { 1 }`},
+		{`assert_error(fn() { 1 + true })`, `"type mismatch: INTEGER + BOOLEAN"`},
+		{`assert_error(fn() { fail("x") })`, `"failed: x"`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestAssertBuiltinsFail(t *testing.T) {
+	tests := []struct {
+		input    string
+		message  string
+		expected string
+		actual   string
+		pos      token.Position
+	}{
+		{
+			input:   `assert(false)`,
+			message: "assertion failed",
+			pos:     token.Position{Line: 1, Column: 1},
+		},
+		{
+			input:   "let x = 1;\n  assert(x > 1, \"x too small\")",
+			message: "assertion failed: x too small",
+			pos:     token.Position{Line: 2, Column: 3},
+		},
+		{
+			input:    `assert_eq([1, 2], [1, 3])`,
+			message:  "assertion failed: expected=[\n1,\n3,\n], actual=[\n1,\n2,\n]",
+			expected: "[\n1,\n3,\n]",
+			actual:   "[\n1,\n2,\n]",
+			pos:      token.Position{Line: 1, Column: 1},
+		},
+		{
+			input:    `let f = fn(x) { assert_eq(x, "b") }; f("a")`,
+			message:  "assertion failed: expected=b, actual=a",
+			expected: "b",
+			actual:   "a",
+			pos:      token.Position{Line: 1, Column: 17},
+		},
+		{
+			input:    `assert_error(fn() { 1 })`,
+			message:  "assertion failed: expected an error, got 1",
+			expected: "ERROR",
+			actual:   "1",
+			pos:      token.Position{Line: 1, Column: 1},
+		},
+		{
+			input:   `fail("boom")`,
+			message: "failed: boom",
+			pos:     token.Position{Line: 1, Column: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if !testErrorObject(t, evaluated, tt.message) {
+			continue
+		}
+
+		err := evaluated.(*object.Error)
+		if err.Expected != tt.expected || err.Actual != tt.actual {
+			t.Errorf("wrong expected/actual. got=%q/%q, want=%q/%q",
+				err.Expected, err.Actual, tt.expected, tt.actual)
+		}
+		if err.Pos != tt.pos {
+			t.Errorf("wrong error position. got=%s, want=%s", err.Pos, tt.pos)
+		}
+	}
+}
+
+func TestAssertBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`assert()`, "wrong number of arguments. got=0, want=1 or 2"},
+		{`assert_eq(1)`, "wrong number of arguments. got=1, want=2"},
+		{`assert_error(1)`, "argument to `assert_error` must be FUNCTION, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
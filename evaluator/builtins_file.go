@@ -0,0 +1,189 @@
+package evaluator
+
+import (
+	"errors"
+	"io/fs"
+	"monkeylang/object"
+)
+
+func (in *Interpreter) fileBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"read_file": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("read_file", args)
+				if err != nil {
+					return err
+				}
+				if in.filesystem == nil {
+					return newError("read_file: %s", errFileSystemDisabled)
+				}
+
+				data, readErr := in.readFile(strs[0])
+				if limitErr, ok := readErr.(*object.Error); ok {
+					return limitErr
+				}
+				if readErr != nil {
+					return newError("read_file: %s", readErr)
+				}
+
+				// The contents were reserved as they were read.
+				if err := in.reserve(1, objectSize); err != nil {
+					return err
+				}
+
+				return &object.String{Value: string(data)}
+			},
+		},
+
+		"write_file": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("write_file", args)
+				if err != nil {
+					return err
+				}
+				if in.filesystem == nil {
+					return newError("write_file: %s", errFileSystemDisabled)
+				}
+
+				if err := in.filesystem.WriteFile(strs[0], []byte(strs[1])); err != nil {
+					return newError("write_file: %s", err)
+				}
+
+				return NULL
+			},
+		},
+
+		"append_file": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("append_file", args)
+				if err != nil {
+					return err
+				}
+				if in.filesystem == nil {
+					return newError("append_file: %s", errFileSystemDisabled)
+				}
+
+				if err := in.filesystem.AppendFile(strs[0], []byte(strs[1])); err != nil {
+					return newError("append_file: %s", err)
+				}
+
+				return NULL
+			},
+		},
+
+		"list_dir": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) > 1 {
+					return newError("wrong number of arguments. got=%d, want=0 or 1",
+						len(args))
+				}
+
+				strs, err := stringArgs("list_dir", args)
+				if err != nil {
+					return err
+				}
+				if in.filesystem == nil {
+					return newError("list_dir: %s", errFileSystemDisabled)
+				}
+
+				dir := "."
+				if len(strs) == 1 {
+					dir = strs[0]
+				}
+
+				entries, readErr := fs.ReadDir(in.filesystem, dir)
+				if readErr != nil {
+					return newError("list_dir: %s", readErr)
+				}
+
+				if err := in.reserveStrings(len(entries)); err != nil {
+					return err
+				}
+
+				names := make([]string, len(entries))
+				for i, entry := range entries {
+					names[i] = entry.Name()
+				}
+
+				return stringsToArray(names)
+			},
+		},
+
+		"exists": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("exists", args)
+				if err != nil {
+					return err
+				}
+				if in.filesystem == nil {
+					return newError("exists: %s", errFileSystemDisabled)
+				}
+
+				_, statErr := fs.Stat(in.filesystem, strs[0])
+				if errors.Is(statErr, fs.ErrNotExist) {
+					return FALSE
+				}
+				if statErr != nil {
+					return newError("exists: %s", statErr)
+				}
+
+				return TRUE
+			},
+		},
+
+		"remove": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("remove", args)
+				if err != nil {
+					return err
+				}
+				if in.filesystem == nil {
+					return newError("remove: %s", errFileSystemDisabled)
+				}
+
+				if err := in.filesystem.Remove(strs[0]); err != nil {
+					return newError("remove: %s", err)
+				}
+
+				return NULL
+			},
+		},
+	}
+}
+
+// readFile reads the file name like fs.ReadFile, reserving its contents
+// against the allocation limits as they are read.
+func (in *Interpreter) readFile(name string) ([]byte, error) {
+	f, err := in.filesystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return in.readAll(f)
+}
@@ -0,0 +1,124 @@
+package evaluator_test
+
+import (
+	"monkeylang/evaluator"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFileBuiltins(t *testing.T) {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, "sub"), 0o755)
+	os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("hello"), 0o644)
+
+	interp := evaluator.New(evaluator.WithFileSystem(evaluator.DirFS(dir)))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`read_file("sub/a.txt")`, `"hello"`},
+		{`exists("sub/a.txt")`, `true`},
+		{`exists("missing.txt")`, `false`},
+		{`list_dir()`, `["sub"]`},
+		{`write_file("b.txt", "one"); read_file("b.txt")`, `"one"`},
+		{`append_file("b.txt", "two"); read_file("b.txt")`, `"onetwo"`},
+		{`append_file("c.txt", "new"); read_file("c.txt")`, `"new"`},
+		{`list_dir(".")`, `["b.txt", "c.txt", "sub"]`},
+		{`remove("b.txt"); exists("b.txt")`, `false`},
+	}
+
+	for _, tt := range tests {
+		testEqualObjectIn(t, interp, tt.input, tt.expected)
+	}
+}
+
+func TestFileBuiltinErrors(t *testing.T) {
+	dir := t.TempDir()
+	interp := evaluator.New(evaluator.WithFileSystem(evaluator.DirFS(dir)))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`read_file("../secret")`, "read_file: open ../secret: invalid argument"},
+		{`write_file("/etc/x", "a")`, "write_file: write /etc/x: invalid argument"},
+		{`remove("../x")`, "remove: remove ../x: invalid argument"},
+		{`read_file("missing")`, "read_file: open missing: no such file or directory"},
+		{`write_file("a", 1)`, "argument to `write_file` must be STRING, got INTEGER"},
+		{`list_dir("a", "b")`, "wrong number of arguments. got=2, want=0 or 1"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEvalIn(interp, tt.input), tt.expected)
+	}
+}
+
+func TestDirFSSymlinks(t *testing.T) {
+	outside := t.TempDir()
+	os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0o644)
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644)
+	links := map[string]string{
+		"inside":   filepath.Join(dir, "a.txt"),
+		"secret":   filepath.Join(outside, "secret"),
+		"out":      outside,
+		"dangling": filepath.Join(outside, "new"),
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Skipf("cannot create symlinks: %s", err)
+		}
+	}
+
+	interp := evaluator.New(evaluator.WithFileSystem(evaluator.DirFS(dir)))
+
+	testEqualObjectIn(t, interp, `read_file("inside")`, `"a"`)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`read_file("secret")`, "read_file: open secret: path is outside the filesystem root"},
+		{`read_file("out/secret")`, "read_file: open out/secret: path is outside the filesystem root"},
+		{`list_dir("out")`, "list_dir: open out: path is outside the filesystem root"},
+		{`write_file("out/new", "x")`, "write_file: write out/new: path is outside the filesystem root"},
+		{`write_file("dangling", "x")`, "write_file: write dangling: path is outside the filesystem root"},
+		{`append_file("secret", "x")`, "append_file: append secret: path is outside the filesystem root"},
+		{`remove("out/secret")`, "remove: remove out/secret: path is outside the filesystem root"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEvalIn(interp, tt.input), tt.expected)
+	}
+
+	if _, err := os.Stat(filepath.Join(outside, "new")); err == nil {
+		t.Errorf("write through a dangling link created a file outside the root")
+	}
+
+	// Removing a link removes the link, not its target.
+	testEqualObjectIn(t, interp, `remove("secret"); exists("secret")`, `false`)
+	if _, err := os.Stat(filepath.Join(outside, "secret")); err != nil {
+		t.Errorf("removing a link removed its target: %s", err)
+	}
+}
+
+func TestFileSystemRestrictions(t *testing.T) {
+	testErrorObject(t, testEval(`read_file("a")`),
+		"read_file: filesystem access is disabled")
+	testErrorObject(t, testEval(`exists("a")`),
+		"exists: filesystem access is disabled")
+
+	interp := evaluator.New(evaluator.WithFileSystem(evaluator.ReadOnly(fstest.MapFS{
+		"a.txt": {Data: []byte("data")},
+	})))
+
+	testEqualObjectIn(t, interp, `read_file("a.txt")`, `"data"`)
+	testErrorObject(t, testEvalIn(interp, `write_file("a.txt", "x")`),
+		"write_file: write a.txt: filesystem is read-only")
+	testErrorObject(t, testEvalIn(interp, `remove("a.txt")`),
+		"remove: remove a.txt: filesystem is read-only")
+}
@@ -0,0 +1,221 @@
+package evaluator
+
+import (
+	"monkeylang/object"
+)
+
+func (in *Interpreter) hashBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"keys": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `keys` must be HASH, got %s", args[0].Type())
+				}
+				if err := in.reserve(1, arraySize(hash.Len())); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, 0, hash.Len())
+				for _, pair := range hash.Pairs() {
+					elements = append(elements, pair.Key)
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"values": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `values` must be HASH, got %s", args[0].Type())
+				}
+				if err := in.reserve(1, arraySize(hash.Len())); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, 0, hash.Len())
+				for _, pair := range hash.Pairs() {
+					elements = append(elements, pair.Value)
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"entries": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `entries` must be HASH, got %s", args[0].Type())
+				}
+				n := hash.Len()
+				if err := in.reserve(n+1, addSize(arraySize(n), mulSize(n, arraySize(2)))); err != nil {
+					return err
+				}
+
+				elements := make([]object.Object, 0, hash.Len())
+				for _, pair := range hash.Pairs() {
+					entry := []object.Object{pair.Key, pair.Value}
+					elements = append(elements, &object.Array{Elements: entry})
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"has": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `has` must be HASH, got %s", args[0].Type())
+				}
+				if _, ok := object.HashKeyOf(args[1]); !ok {
+					return newError("unusable as hash key: %s", args[1].Type())
+				}
+
+				_, ok = hash.Get(args[1])
+				return nativeBoolToBooleanObject(ok)
+			},
+		},
+
+		"get": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 && len(args) != 3 {
+					return newError("wrong number of arguments. got=%d, want=2 or 3",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `get` must be HASH, got %s", args[0].Type())
+				}
+				if _, ok := object.HashKeyOf(args[1]); !ok {
+					return newError("unusable as hash key: %s", args[1].Type())
+				}
+
+				if value, ok := hash.Get(args[1]); ok {
+					return value
+				}
+				if len(args) == 3 {
+					return args[2]
+				}
+
+				return NULL
+			},
+		},
+
+		"set": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 3 {
+					return newError("wrong number of arguments. got=%d, want=3",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `set` must be HASH, got %s", args[0].Type())
+				}
+				if err := in.reserve(1, hashSize(hash.Len()+1)); err != nil {
+					return err
+				}
+
+				newHash := copyHash(hash)
+				if !newHash.Set(args[1], args[2]) {
+					return newError("unusable as hash key: %s", args[1].Type())
+				}
+
+				return newHash
+			},
+		},
+
+		"delete": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				hash, ok := args[0].(*object.Hash)
+				if !ok {
+					return newError("argument to `delete` must be HASH, got %s", args[0].Type())
+				}
+				if _, ok := object.HashKeyOf(args[1]); !ok {
+					return newError("unusable as hash key: %s", args[1].Type())
+				}
+				if err := in.reserve(1, hashSize(hash.Len())); err != nil {
+					return err
+				}
+
+				newHash := object.NewHash()
+				for _, pair := range hash.Pairs() {
+					if !object.Equal(pair.Key, args[1]) {
+						newHash.Set(pair.Key, pair.Value)
+					}
+				}
+
+				return newHash
+			},
+		},
+
+		"merge": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) < 1 {
+					return newError("wrong number of arguments. got=%d, want>=1",
+						len(args))
+				}
+
+				pairs := 0
+				for _, arg := range args {
+					hash, ok := arg.(*object.Hash)
+					if !ok {
+						return newError("argument to `merge` must be HASH, got %s", arg.Type())
+					}
+					pairs = addSize(pairs, hash.Len())
+				}
+				if err := in.reserve(1, hashSize(pairs)); err != nil {
+					return err
+				}
+
+				newHash := object.NewHash()
+				for _, arg := range args {
+					for _, pair := range arg.(*object.Hash).Pairs() {
+						newHash.Set(pair.Key, pair.Value)
+					}
+				}
+
+				return newHash
+			},
+		},
+	}
+}
+
+func copyHash(hash *object.Hash) *object.Hash {
+	newHash := object.NewHash()
+	for _, pair := range hash.Pairs() {
+		newHash.Set(pair.Key, pair.Value)
+	}
+
+	return newHash
+}
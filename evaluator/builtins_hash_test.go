@@ -0,0 +1,55 @@
+package evaluator_test
+
+import "testing"
+
+func TestHashBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`keys({"b": 1, "a": 2})`, `["b", "a"]`},
+		{`keys({})`, `[]`},
+		{`values({"b": 1, "a": 2})`, `[1, 2]`},
+		{`entries({"b": 1, 2: "a"})`, `[["b", 1], [2, "a"]]`},
+		{`has({"a": 1}, "a")`, `true`},
+		{`has({"a": 1}, "b")`, `false`},
+		{`has({[1, 2]: 1}, [1, 2])`, `true`},
+		{`get({"a": 1}, "a")`, `1`},
+		{`get({"a": 1}, "b")`, `if (false) { 1 }`},
+		{`get({"a": 1}, "b", 5)`, `5`},
+		{`set({"a": 1}, "b", 2)`, `{"a": 1, "b": 2}`},
+		{`set({"a": 1}, "a", 2)`, `{"a": 2}`},
+		{`let h = {"a": 1}; set(h, "a", 2); h`, `{"a": 1}`},
+		{`delete({"a": 1, "b": 2}, "a")`, `{"b": 2}`},
+		{`delete({"a": 1}, "c")`, `{"a": 1}`},
+		{`let h = {"a": 1}; delete(h, "a"); h`, `{"a": 1}`},
+		{`merge({"a": 1, "b": 2}, {"b": 3, "c": 4})`, `{"a": 1, "b": 3, "c": 4}`},
+		{`merge({"a": 1})`, `{"a": 1}`},
+		{`keys(merge({"b": 1}, {"a": 2}))`, `["b", "a"]`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestHashBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`keys([1])`, "argument to `keys` must be HASH, got ARRAY"},
+		{`values()`, "wrong number of arguments. got=0, want=1"},
+		{`entries(1)`, "argument to `entries` must be HASH, got INTEGER"},
+		{`has({}, fn(x) { x })`, "unusable as hash key: FUNCTION"},
+		{`get({})`, "wrong number of arguments. got=1, want=2 or 3"},
+		{`set({}, {}, 1)`, "unusable as hash key: HASH"},
+		{`delete("a", "a")`, "argument to `delete` must be HASH, got STRING"},
+		{`merge()`, "wrong number of arguments. got=0, want>=1"},
+		{`merge({}, [])`, "argument to `merge` must be HASH, got ARRAY"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
@@ -0,0 +1,108 @@
+package evaluator
+
+import (
+	"fmt"
+	"io"
+	"monkeylang/object"
+	"strings"
+)
+
+func (in *Interpreter) ioBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"puts": {
+			Fn: func(args ...object.Object) object.Object {
+				for _, arg := range args {
+					fmt.Fprintln(in.stdout, arg.Inspect())
+				}
+
+				return NULL
+			},
+		},
+
+		"print": {
+			Fn: func(args ...object.Object) object.Object {
+				for _, arg := range args {
+					io.WriteString(in.stdout, arg.Inspect())
+				}
+
+				return NULL
+			},
+		},
+
+		"eprint": {
+			Fn: func(args ...object.Object) object.Object {
+				for _, arg := range args {
+					io.WriteString(in.stderr, arg.Inspect())
+				}
+
+				return NULL
+			},
+		},
+
+		"read_line": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newError("wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				line, err := in.stdin.ReadString('\n')
+				if err != nil && err != io.EOF {
+					return newError("read_line: %s", err)
+				}
+				if err == io.EOF && line == "" {
+					return NULL
+				}
+
+				line = strings.TrimSuffix(line, "\n")
+				line = strings.TrimSuffix(line, "\r")
+
+				return in.charge(&object.String{Value: line})
+			},
+		},
+
+		"read_all": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newError("wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				data, err := in.readAll(in.stdin)
+				if limitErr, ok := err.(*object.Error); ok {
+					return limitErr
+				}
+				if err != nil {
+					return newError("read_all: %s", err)
+				}
+
+				// The contents were reserved as they were read.
+				if err := in.reserve(1, objectSize); err != nil {
+					return err
+				}
+
+				return &object.String{Value: string(data)}
+			},
+		},
+
+		"exit": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) > 1 {
+					return newError("wrong number of arguments. got=%d, want=0 or 1",
+						len(args))
+				}
+
+				if len(args) == 0 {
+					return &object.Exit{Code: 0}
+				}
+
+				code, ok := args[0].(*object.Integer)
+				if !ok {
+					return newError("argument to `exit` must be INTEGER, got %s", args[0].Type())
+				}
+
+				return &object.Exit{Code: code.Value}
+			},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package evaluator_test
+
+import (
+	"bytes"
+	"monkeylang/evaluator"
+	"monkeylang/object"
+	"strings"
+	"testing"
+)
+
+func TestOutputBuiltins(t *testing.T) {
+	var out, errOut bytes.Buffer
+	interp := evaluator.New(evaluator.WithStdout(&out), evaluator.WithStderr(&errOut))
+
+	testEvalIn(interp, `puts("a", 1); print("b", 2); print("\n"); eprint("oops")`)
+
+	if out.String() != "a\n1\nb2\n" {
+		t.Errorf("stdout wrong. got=%q", out.String())
+	}
+	if errOut.String() != "oops" {
+		t.Errorf("stderr wrong. got=%q", errOut.String())
+	}
+}
+
+func TestInputBuiltins(t *testing.T) {
+	interp := evaluator.New(evaluator.WithStdin(strings.NewReader("first\r\nsecond\nrest\nof it")))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`read_line()`, `"first"`},
+		{`read_line()`, `"second"`},
+		{`read_all()`, `"rest
+of it"`},
+		{`read_line()`, `if (false) { 1 }`},
+		{`read_all()`, `""`},
+	}
+
+	for _, tt := range tests {
+		testEqualObjectIn(t, interp, tt.input, tt.expected)
+	}
+}
+
+func TestExit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{`exit(); 5`, 0},
+		{`exit(3); 5`, 3},
+		{`let f = fn() { exit(2); 1 }; f(); 5`, 2},
+		{`map([1, 2], fn(x) { exit(x) }); 5`, 1},
+		{`[1, exit(4)]; 5`, 4},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		exit, ok := evaluated.(*object.Exit)
+		if !ok {
+			t.Errorf("object is not Exit. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if exit.Code != tt.expected {
+			t.Errorf("wrong exit code. got=%d, want=%d", exit.Code, tt.expected)
+		}
+	}
+
+	testErrorObject(t, testEval(`exit("a")`), "argument to `exit` must be INTEGER, got STRING")
+}
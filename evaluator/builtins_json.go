@@ -0,0 +1,273 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"monkeylang/object"
+	"strconv"
+	"strings"
+)
+
+// maxJSONIndent is the widest indent, in spaces, `json_encode` accepts.
+const maxJSONIndent = 16
+
+func (in *Interpreter) jsonBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"json_encode": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				indent := ""
+				if len(args) == 2 {
+					switch arg := args[1].(type) {
+					case *object.Integer:
+						if arg.Value < 0 || arg.Value > maxJSONIndent {
+							return newError("indent for `json_encode` must be between 0 and %d, got %d",
+								maxJSONIndent, arg.Value)
+						}
+						indent = strings.Repeat(" ", int(arg.Value))
+					case *object.String:
+						indent = arg.Value
+					default:
+						return newError("argument to `json_encode` must be INTEGER or STRING, got %s",
+							args[1].Type())
+					}
+				}
+
+				enc := &jsonEncoder{in: in, indent: indent}
+				if err := enc.encode(args[0]); err != nil {
+					if limitErr, ok := err.(*object.Error); ok {
+						return limitErr
+					}
+					return newError("json_encode: %s", err)
+				}
+				if err := in.reserve(1, objectSize); err != nil {
+					return err
+				}
+
+				return &object.String{Value: enc.out.String()}
+			},
+		},
+
+		"json_decode": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				str, ok := args[0].(*object.String)
+				if !ok {
+					return newError("argument to `json_decode` must be STRING, got %s",
+						args[0].Type())
+				}
+
+				dec := json.NewDecoder(strings.NewReader(str.Value))
+				dec.UseNumber()
+
+				value, err := decodeJSON(dec)
+				if err != nil {
+					return newError("json_decode: %s", err)
+				}
+				if _, err := dec.Token(); err != io.EOF {
+					return newError("json_decode: unexpected data after top-level value")
+				}
+
+				return value
+			},
+		},
+	}
+}
+
+// jsonEncoder writes objects as JSON. Hash keys are written in insertion
+// order; integer and boolean keys are converted to strings. With an indent
+// the output is laid out like json.Indent lays it out.
+//
+// Values shared within obj are written out each time, so the output can
+// be far larger than obj; it is reserved against the interpreter's limits
+// as it grows.
+type jsonEncoder struct {
+	in       *Interpreter
+	out      bytes.Buffer
+	indent   string
+	depth    int
+	reserved int
+}
+
+// encode writes obj. A limit being exceeded is reported as the
+// *object.Error itself.
+func (e *jsonEncoder) encode(obj object.Object) error {
+	if grown := e.out.Len() - e.reserved; grown > 0 {
+		if err := e.in.reserve(0, grown); err != nil {
+			return err
+		}
+		e.reserved = e.out.Len()
+	}
+
+	switch obj := obj.(type) {
+	case *object.Null:
+		e.out.WriteString("null")
+
+	case *object.Boolean:
+		e.out.WriteString(strconv.FormatBool(obj.Value))
+
+	case *object.Integer:
+		e.out.WriteString(strconv.FormatInt(obj.Value, 10))
+
+	case *object.String:
+		// Unlike json.Marshal, leave <, > and & alone: the output is
+		// data, not HTML.
+		enc := json.NewEncoder(&e.out)
+		enc.SetEscapeHTML(false)
+		enc.Encode(obj.Value)
+		e.out.Truncate(e.out.Len() - 1) // the newline Encode appends
+
+	case *object.Array:
+		if len(obj.Elements) == 0 {
+			e.out.WriteString("[]")
+			break
+		}
+
+		e.out.WriteByte('[')
+		e.depth++
+		for i, el := range obj.Elements {
+			if i > 0 {
+				e.out.WriteByte(',')
+			}
+			e.newline()
+			if err := e.encode(el); err != nil {
+				return err
+			}
+		}
+		e.depth--
+		e.newline()
+		e.out.WriteByte(']')
+
+	case *object.Hash:
+		if obj.Len() == 0 {
+			e.out.WriteString("{}")
+			break
+		}
+
+		e.out.WriteByte('{')
+		e.depth++
+		for i, pair := range obj.Pairs() {
+			if i > 0 {
+				e.out.WriteByte(',')
+			}
+			e.newline()
+
+			var key object.Object
+			switch pair.Key.(type) {
+			case *object.String:
+				key = pair.Key
+			case *object.Integer, *object.Boolean:
+				key = &object.String{Value: pair.Key.Inspect()}
+			default:
+				return fmt.Errorf("unsupported key type %s", pair.Key.Type())
+			}
+			if err := e.encode(key); err != nil {
+				return err
+			}
+
+			e.out.WriteByte(':')
+			if e.indent != "" {
+				e.out.WriteByte(' ')
+			}
+			if err := e.encode(pair.Value); err != nil {
+				return err
+			}
+		}
+		e.depth--
+		e.newline()
+		e.out.WriteByte('}')
+
+	default:
+		return fmt.Errorf("value of type %s is not serializable", obj.Type())
+	}
+
+	return nil
+}
+
+// newline starts a new line at the current depth when indenting.
+func (e *jsonEncoder) newline() {
+	if e.indent == "" {
+		return
+	}
+
+	e.out.WriteByte('\n')
+	for range e.depth {
+		e.out.WriteString(e.indent)
+	}
+}
+
+// decodeJSON reads the next JSON value from dec. Objects become hashes that
+// keep the key order of the input.
+func decodeJSON(dec *json.Decoder) (object.Object, error) {
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil, errors.New("unexpected end of input")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := tok.(type) {
+	case nil:
+		return NULL, nil
+
+	case bool:
+		return nativeBoolToBooleanObject(tok), nil
+
+	case string:
+		return &object.String{Value: tok}, nil
+
+	case json.Number:
+		value, err := strconv.ParseInt(tok.String(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("number %s is not an integer", tok)
+		}
+		return &object.Integer{Value: value}, nil
+
+	case json.Delim:
+		if tok == '[' {
+			elements := []object.Object{}
+			for dec.More() {
+				el, err := decodeJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				elements = append(elements, el)
+			}
+			dec.Token()
+
+			return &object.Array{Elements: elements}, nil
+		}
+
+		hash := object.NewHash()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			hash.Set(&object.String{Value: key.(string)}, value)
+		}
+		dec.Token()
+
+		return hash, nil
+	}
+
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
@@ -0,0 +1,56 @@
+package evaluator_test
+
+import "testing"
+
+func TestJSONBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`json_encode(1)`, `"1"`},
+		{`json_encode("a")`, `"\"a\""`},
+		{`json_encode([1, true, "x"])`, `"[1,true,\"x\"]"`},
+		{`json_encode(if (false) { 1 })`, `"null"`},
+		{`json_encode({"b": 1, "a": [2], 3: false})`, `"{\"b\":1,\"a\":[2],\"3\":false}"`},
+		{`json_encode({"a": [1]}, 2)`, `"{
+  \"a\": [
+    1
+  ]
+}"`},
+		{`json_encode([], "\t")`, `"[]"`},
+		{`json_encode("<a & b>")`, `"\"<a & b>\""`},
+		{`json_decode("42")`, `42`},
+		{`json_decode("[1, \"a\", true, null]")`, `[1, "a", true, if (false) { 1 }]`},
+		{`json_decode("{\"z\": 1, \"a\": {\"b\": []}}")`, `{"z": 1, "a": {"b": []}}`},
+		{`keys(json_decode("{\"z\": 1, \"a\": 2}"))`, `["z", "a"]`},
+		{`let v = {"a": [1, "b"]}; json_decode(json_encode(v))`, `{"a": [1, "b"]}`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestJSONBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`json_encode(fn(x) { x })`, "json_encode: value of type FUNCTION is not serializable"},
+		{`json_encode([len])`, "json_encode: value of type BUILTIN is not serializable"},
+		{`json_encode({[1]: 1})`, "json_encode: unsupported key type ARRAY"},
+		{`json_encode(1, -1)`, "indent for `json_encode` must be between 0 and 16, got -1"},
+		{`json_encode(1, 4611686018427387904)`,
+			"indent for `json_encode` must be between 0 and 16, got 4611686018427387904"},
+		{`json_encode(1, true)`, "argument to `json_encode` must be INTEGER or STRING, got BOOLEAN"},
+		{`json_decode(1)`, "argument to `json_decode` must be STRING, got INTEGER"},
+		{`json_decode("1.5")`, "json_decode: number 1.5 is not an integer"},
+		{`json_decode("")`, "json_decode: unexpected end of input"},
+		{`json_decode("[1")`, "json_decode: unexpected end of JSON input"},
+		{`json_decode("1 2")`, "json_decode: unexpected data after top-level value"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
@@ -0,0 +1,122 @@
+package evaluator
+
+import (
+	"math"
+	"monkeylang/object"
+	"slices"
+)
+
+func (in *Interpreter) randomBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"random": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newError("wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				return &object.Integer{Value: in.rng.Int63()}
+			},
+		},
+
+		"rand_int": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				values, err := integerArgs("rand_int", args)
+				if err != nil {
+					return err
+				}
+
+				lo, hi := values[0], values[1]
+				if lo >= hi {
+					return newError("`rand_int` range is empty, got %d >= %d", lo, hi)
+				}
+
+				return &object.Integer{Value: lo + int64(in.randUint64n(uint64(hi)-uint64(lo)))}
+			},
+		},
+
+		"shuffle": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `shuffle` must be ARRAY, got %s", args[0].Type())
+				}
+
+				if err := in.reserve(1, arraySize(len(arr.Elements))); err != nil {
+					return err
+				}
+
+				elements := slices.Clone(arr.Elements)
+				in.rng.Shuffle(len(elements), func(i, j int) {
+					elements[i], elements[j] = elements[j], elements[i]
+				})
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"choice": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `choice` must be ARRAY, got %s", args[0].Type())
+				}
+				if len(arr.Elements) == 0 {
+					return newError("`choice` of empty ARRAY")
+				}
+
+				return arr.Elements[in.rng.Intn(len(arr.Elements))]
+			},
+		},
+
+		"seed": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				values, err := integerArgs("seed", args)
+				if err != nil {
+					return err
+				}
+
+				in.rng.Seed(values[0])
+
+				return NULL
+			},
+		},
+	}
+}
+
+// randUint64n returns a random number in [0, n), for n > 0. Spans that
+// fit an int64 use Int63n, so seeded results stay the same; wider ones,
+// such as a range from math.MinInt64, are drawn by rejection sampling.
+func (in *Interpreter) randUint64n(n uint64) uint64 {
+	if n <= math.MaxInt64 {
+		return uint64(in.rng.Int63n(int64(n)))
+	}
+
+	// n is over half the range of Uint64, so each draw is accepted with
+	// probability over one half.
+	for {
+		if v := in.rng.Uint64(); v < n {
+			return v
+		}
+	}
+}
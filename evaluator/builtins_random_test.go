@@ -0,0 +1,62 @@
+package evaluator_test
+
+import (
+	"monkeylang/evaluator"
+	"monkeylang/object"
+	"testing"
+)
+
+func TestRandomBuiltinsAreReproducible(t *testing.T) {
+	input := `[random(), rand_int(10, 20), shuffle([1, 2, 3, 4, 5]), choice(["a", "b", "c"])]`
+
+	first := testEvalIn(evaluator.New(evaluator.WithSeed(42)), input)
+	second := testEvalIn(evaluator.New(evaluator.WithSeed(42)), input)
+
+	if !object.Equal(first, second) {
+		t.Errorf("same seed gave different results. first=%s, second=%s",
+			first.Inspect(), second.Inspect())
+	}
+
+	testEqualObject(t,
+		`seed(7); let a = random(); seed(7); a == random()`, `true`)
+}
+
+func TestRandomBuiltins(t *testing.T) {
+	interp := evaluator.New(evaluator.WithSeed(1))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let n = rand_int(3, 5); any([3, 4], fn(x) { x == n })`, `true`},
+		{`rand_int(3, 4)`, `3`},
+		{`rand_int(9223372036854775806, 9223372036854775807)`, `9223372036854775806`},
+		{`let n = rand_int(-9223372036854775807 - 1, 9223372036854775807); n < 9223372036854775807`, `true`},
+		{`let n = rand_int(-9223372036854775807 - 1, -9223372036854775807); n`, `-9223372036854775807 - 1`},
+		{`random() < 0`, `false`},
+		{`sort(shuffle([3, 1, 2]))`, `[1, 2, 3]`},
+		{`let a = [1, 2, 3]; shuffle(a); a`, `[1, 2, 3]`},
+		{`choice([9])`, `9`},
+	}
+
+	for _, tt := range tests {
+		testEqualObjectIn(t, interp, tt.input, tt.expected)
+	}
+}
+
+func TestRandomBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`rand_int(5, 5)`, "`rand_int` range is empty, got 5 >= 5"},
+		{`rand_int(1)`, "wrong number of arguments. got=1, want=2"},
+		{`shuffle(1)`, "argument to `shuffle` must be ARRAY, got INTEGER"},
+		{`choice([])`, "`choice` of empty ARRAY"},
+		{`seed("a")`, "argument to `seed` must be INTEGER, got STRING"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
@@ -0,0 +1,245 @@
+package evaluator
+
+import (
+	"monkeylang/object"
+	"regexp"
+	"sync"
+)
+
+// maxCachedRegexps bounds the pattern cache; it is cleared when full.
+const maxCachedRegexps = 256
+
+// regexpCache memoizes compiled patterns so scripts calling the `re_*`
+// builtins in a loop do not recompile them on every call.
+type regexpCache struct {
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+func newRegexpCache() *regexpCache {
+	return &regexpCache{patterns: make(map[string]*regexp.Regexp)}
+}
+
+func (c *regexpCache) compile(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if re, ok := c.patterns[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.patterns) >= maxCachedRegexps {
+		clear(c.patterns)
+	}
+	c.patterns[pattern] = re
+
+	return re, nil
+}
+
+func (in *Interpreter) regexpBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"re_match": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				re, s, err := in.regexpArgs("re_match", args)
+				if err != nil {
+					return err
+				}
+
+				match := re.FindStringSubmatchIndex(s)
+				if match == nil {
+					return NULL
+				}
+
+				// The hash and its four keys, the match and its index, the
+				// groups array, the named hash and a string per group.
+				groups := re.NumSubexp()
+				size := addSize(hashSize(4), addSize(hashSize(groups), arraySize(groups)))
+				if err := in.reserve(groups+9, addSize(size, mulSize(groups+6, objectSize))); err != nil {
+					return err
+				}
+
+				return matchToHash(re, s, match)
+			},
+		},
+
+		"re_find_all": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				re, s, err := in.regexpArgs("re_find_all", args)
+				if err != nil {
+					return err
+				}
+
+				matches := re.FindAllStringSubmatchIndex(s, -1)
+
+				// Each match is a string, or an array of a string per
+				// group.
+				perMatch, matchSize := 1, objectSize
+				if groups := re.NumSubexp(); groups > 1 {
+					perMatch = groups + 1
+					matchSize = addSize(arraySize(groups), mulSize(groups, objectSize))
+				}
+				n := len(matches)
+				if err := in.reserve(addSize(mulSize(n, perMatch), 1), addSize(arraySize(n), mulSize(n, matchSize))); err != nil {
+					return err
+				}
+
+				// As with `re_match`, groups that did not take part in a
+				// match are null.
+				elements := make([]object.Object, 0, n)
+				for _, match := range matches {
+					switch groups := len(match)/2 - 1; groups {
+					case 0:
+						elements = append(elements, matchGroup(s, match, 0))
+					case 1:
+						elements = append(elements, matchGroup(s, match, 1))
+					default:
+						tuple := make([]object.Object, groups)
+						for i := range tuple {
+							tuple[i] = matchGroup(s, match, i+1)
+						}
+						elements = append(elements, &object.Array{Elements: tuple})
+					}
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+
+		"re_replace": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 3 {
+					return newError("wrong number of arguments. got=%d, want=3",
+						len(args))
+				}
+
+				re, s, err := in.regexpArgs("re_replace", args[:2])
+				if err != nil {
+					return err
+				}
+
+				switch repl := args[2].(type) {
+				case *object.String:
+					// Group references in repl are counted at their
+					// length in repl, not at the length they expand to.
+					matches := len(re.FindAllStringIndex(s, -1))
+					if err := in.reserve(1, stringSize(addSize(len(s), mulSize(matches, len(repl.Value))))); err != nil {
+						return err
+					}
+
+					return &object.String{Value: re.ReplaceAllString(s, repl.Value)}
+
+				case *object.Function, *object.Builtin:
+					var replErr object.Object
+					result := re.ReplaceAllStringFunc(s, func(match string) string {
+						if replErr != nil {
+							return ""
+						}
+
+						replaced := in.applyFunction(repl, []object.Object{&object.String{Value: match}})
+						if isError(replaced) {
+							replErr = replaced
+							return ""
+						}
+
+						return replaced.Inspect()
+					})
+					if replErr != nil {
+						return replErr
+					}
+
+					// The replacements were created, and counted, by repl.
+					return in.charge(&object.String{Value: result})
+
+				default:
+					return newError("argument to `re_replace` must be STRING or FUNCTION, got %s",
+						args[2].Type())
+				}
+			},
+		},
+
+		"re_split": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				re, s, err := in.regexpArgs("re_split", args)
+				if err != nil {
+					return err
+				}
+
+				parts := re.Split(s, -1)
+				if err := in.reserveStrings(len(parts)); err != nil {
+					return err
+				}
+
+				return stringsToArray(parts)
+			},
+		},
+	}
+}
+
+// regexpArgs validates the (pattern, string) arguments shared by the `re_*`
+// builtins and compiles the pattern through the cache.
+func (in *Interpreter) regexpArgs(name string, args []object.Object) (*regexp.Regexp, string, *object.Error) {
+	strs, err := stringArgs(name, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	re, compileErr := in.regexps.compile(strs[0])
+	if compileErr != nil {
+		return nil, "", newError("invalid regular expression: %s", compileErr)
+	}
+
+	return re, strs[1], nil
+}
+
+// matchToHash describes a match as a hash with the whole match, its start
+// index, the positional groups and the named groups. Groups that did not
+// take part in the match are null.
+func matchToHash(re *regexp.Regexp, s string, match []int) *object.Hash {
+	groups := make([]object.Object, 0, re.NumSubexp())
+	named := object.NewHash()
+	for i, name := range re.SubexpNames()[1:] {
+		groups = append(groups, matchGroup(s, match, i+1))
+		if name != "" {
+			named.Set(&object.String{Value: name}, matchGroup(s, match, i+1))
+		}
+	}
+
+	hash := object.NewHash()
+	hash.Set(&object.String{Value: "match"}, matchGroup(s, match, 0))
+	hash.Set(&object.String{Value: "index"}, &object.Integer{Value: int64(match[0])})
+	hash.Set(&object.String{Value: "groups"}, &object.Array{Elements: groups})
+	hash.Set(&object.String{Value: "named"}, named)
+
+	return hash
+}
+
+// matchGroup returns group i of match, a result of one of the
+// FindSubmatchIndex methods on s, or null if the group did not take part
+// in the match.
+func matchGroup(s string, match []int, i int) object.Object {
+	if match[2*i] < 0 {
+		return NULL
+	}
+
+	return &object.String{Value: s[match[2*i]:match[2*i+1]]}
+}
@@ -0,0 +1,48 @@
+package evaluator_test
+
+import "testing"
+
+func TestRegexpBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`re_match("\d+", "abc")`, `if (false) { 1 }`},
+		{`re_match("b(\d+)", "ab12c")`,
+			`{"match": "b12", "index": 1, "groups": ["12"], "named": {}}`},
+		{`re_match("(?P<key>\w+)=(?P<value>\w*)(x)?", "a=1")["named"]`,
+			`{"key": "a", "value": "1"}`},
+		{`re_match("(a)(x)?", "a")["groups"]`, `["a", if (false) { 1 }]`},
+		{`re_find_all("\d+", "a1b22c333")`, `["1", "22", "333"]`},
+		{`re_find_all("(\w)=(\d)", "a=1, b=2")`, `[["a", "1"], ["b", "2"]]`},
+		{`re_find_all("x(\d)", "x1 x2")`, `["1", "2"]`},
+		{`re_find_all("(\w)(\d)?", "a1 b")`, `[["a", "1"], ["b", if (false) { 1 }]]`},
+		{`re_find_all("x(\d)?", "x1 x")`, `["1", if (false) { 1 }]`},
+		{`re_find_all("z", "abc")`, `[]`},
+		{`re_replace("(\w+)@(\w+)", "me@host", "$2 at $1")`, `"host at me"`},
+		{`re_replace("\d", "a1b2", fn(d) { int(d) * 2 })`, `"a2b4"`},
+		{`re_split("\s*,\s*", "a , b,c")`, `["a", "b", "c"]`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestRegexpBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`re_match("(", "a")`,
+			"invalid regular expression: error parsing regexp: missing closing ): `(`"},
+		{`re_match("a")`, "wrong number of arguments. got=1, want=2"},
+		{`re_split(1, "a")`, "argument to `re_split` must be STRING, got INTEGER"},
+		{`re_replace("a", "a", 1)`, "argument to `re_replace` must be STRING or FUNCTION, got INTEGER"},
+		{`re_replace("a", "a", fn(m) { -m })`, "unknown operator: -STRING"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
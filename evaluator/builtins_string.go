@@ -0,0 +1,420 @@
+package evaluator
+
+import (
+	"math"
+	"monkeylang/object"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func (in *Interpreter) stringBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"split": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				strs, err := stringArgs("split", args)
+				if err != nil {
+					return err
+				}
+
+				var parts []string
+				if len(strs) == 1 {
+					if err := in.reserveStrings(countFields(strs[0])); err != nil {
+						return err
+					}
+					parts = strings.Fields(strs[0])
+				} else {
+					if err := in.reserveStrings(countSplit(strs[0], strs[1])); err != nil {
+						return err
+					}
+					parts = strings.Split(strs[0], strs[1])
+				}
+
+				return stringsToArray(parts)
+			},
+		},
+
+		"trim": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				strs, err := stringArgs("trim", args)
+				if err != nil {
+					return err
+				}
+
+				if len(strs) == 1 {
+					return in.charge(&object.String{Value: strings.TrimSpace(strs[0])})
+				}
+
+				return in.charge(&object.String{Value: strings.Trim(strs[0], strs[1])})
+			},
+		},
+
+		"upper": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("upper", args)
+				if err != nil {
+					return err
+				}
+				if err := in.reserve(1, stringSize(len(strs[0]))); err != nil {
+					return err
+				}
+
+				return &object.String{Value: strings.ToUpper(strs[0])}
+			},
+		},
+
+		"lower": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("lower", args)
+				if err != nil {
+					return err
+				}
+				if err := in.reserve(1, stringSize(len(strs[0]))); err != nil {
+					return err
+				}
+
+				return &object.String{Value: strings.ToLower(strs[0])}
+			},
+		},
+
+		"contains": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("contains", args)
+				if err != nil {
+					return err
+				}
+
+				return nativeBoolToBooleanObject(strings.Contains(strs[0], strs[1]))
+			},
+		},
+
+		"index_of": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("index_of", args)
+				if err != nil {
+					return err
+				}
+
+				return &object.Integer{Value: int64(strings.Index(strs[0], strs[1]))}
+			},
+		},
+
+		"replace": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 3 {
+					return newError("wrong number of arguments. got=%d, want=3",
+						len(args))
+				}
+
+				strs, err := stringArgs("replace", args)
+				if err != nil {
+					return err
+				}
+				if err := in.reserve(1, stringSize(replaceLen(strs[0], strs[1], strs[2]))); err != nil {
+					return err
+				}
+
+				return &object.String{Value: strings.ReplaceAll(strs[0], strs[1], strs[2])}
+			},
+		},
+
+		"starts_with": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("starts_with", args)
+				if err != nil {
+					return err
+				}
+
+				return nativeBoolToBooleanObject(strings.HasPrefix(strs[0], strs[1]))
+			},
+		},
+
+		"ends_with": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("ends_with", args)
+				if err != nil {
+					return err
+				}
+
+				return nativeBoolToBooleanObject(strings.HasSuffix(strs[0], strs[1]))
+			},
+		},
+
+		"repeat": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				strs, err := stringArgs("repeat", args[:1])
+				if err != nil {
+					return err
+				}
+
+				count, ok := args[1].(*object.Integer)
+				if !ok {
+					return newError("argument to `repeat` must be INTEGER, got %s", args[1].Type())
+				}
+				if count.Value < 0 {
+					return newError("argument to `repeat` must not be negative, got %d",
+						count.Value)
+				}
+				if len(strs[0]) > 0 && count.Value > math.MaxInt/int64(len(strs[0])) {
+					return newError("`repeat` result is too long: %d copies of %d bytes",
+						count.Value, len(strs[0]))
+				}
+				if err := in.reserve(1, stringSize(len(strs[0])*int(count.Value))); err != nil {
+					return err
+				}
+
+				return &object.String{Value: strings.Repeat(strs[0], int(count.Value))}
+			},
+		},
+
+		"chars": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("chars", args)
+				if err != nil {
+					return err
+				}
+
+				if err := in.reserveStrings(countSplit(strs[0], "")); err != nil {
+					return err
+				}
+
+				return stringsToArray(strings.Split(strs[0], ""))
+			},
+		},
+
+		"ord": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				strs, err := stringArgs("ord", args)
+				if err != nil {
+					return err
+				}
+
+				if utf8.RuneCountInString(strs[0]) != 1 {
+					return newError("argument to `ord` must be a single character, got %q",
+						strs[0])
+				}
+
+				r, _ := utf8.DecodeRuneInString(strs[0])
+				return &object.Integer{Value: int64(r)}
+			},
+		},
+
+		"chr": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				code, ok := args[0].(*object.Integer)
+				if !ok {
+					return newError("argument to `chr` must be INTEGER, got %s", args[0].Type())
+				}
+				if code.Value < 0 || code.Value > utf8.MaxRune {
+					return newError("argument to `chr` out of range, got %d", code.Value)
+				}
+
+				return in.charge(&object.String{Value: string(rune(code.Value))})
+			},
+		},
+
+		"format": {
+			Fn: func(args ...object.Object) object.Object {
+				return in.formatBuiltin("format", args)
+			},
+		},
+
+		"sprintf": {
+			Fn: func(args ...object.Object) object.Object {
+				return in.formatBuiltin("sprintf", args)
+			},
+		},
+	}
+}
+
+// stringArgs checks that every argument is a STRING and returns their values.
+func stringArgs(name string, args []object.Object) ([]string, *object.Error) {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		str, ok := arg.(*object.String)
+		if !ok {
+			return nil, newError("argument to `%s` must be STRING, got %s",
+				name, arg.Type())
+		}
+		strs[i] = str.Value
+	}
+
+	return strs, nil
+}
+
+// reserveStrings reserves an array of n new strings that share the bytes
+// of an existing one, as those `split` returns.
+func (in *Interpreter) reserveStrings(n int) *object.Error {
+	return in.reserve(addSize(n, 1), addSize(arraySize(n), mulSize(n, objectSize)))
+}
+
+// countSplit returns the number of parts strings.Split(s, sep) returns.
+func countSplit(s, sep string) int {
+	if sep == "" {
+		return utf8.RuneCountInString(s)
+	}
+
+	return strings.Count(s, sep) + 1
+}
+
+// replaceLen returns the length of strings.ReplaceAll(s, old, new).
+func replaceLen(s, old, new string) int {
+	n := strings.Count(s, old)
+	return addSize(len(s)-n*len(old), mulSize(n, len(new)))
+}
+
+// countFields returns the number of parts strings.Fields(s) returns.
+func countFields(s string) int {
+	n := 0
+	inField := false
+	for _, r := range s {
+		space := unicode.IsSpace(r)
+		if !space && !inField {
+			n++
+		}
+		inField = !space
+	}
+
+	return n
+}
+
+func stringsToArray(strs []string) *object.Array {
+	elements := make([]object.Object, len(strs))
+	for i, s := range strs {
+		elements[i] = &object.String{Value: s}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// formatBuiltin expands a format string with the %d, %s, %v and %% verbs.
+// %d takes an INTEGER, %s a STRING and %v any value.
+func (in *Interpreter) formatBuiltin(name string, args []object.Object) object.Object {
+	if len(args) < 1 {
+		return newError("wrong number of arguments. got=%d, want>=1",
+			len(args))
+	}
+
+	strs, err := stringArgs(name, args[:1])
+	if err != nil {
+		return err
+	}
+	format, values := strs[0], args[1:]
+
+	length := len(format)
+	seen := map[object.Object]int{}
+	for _, value := range values {
+		length = addSize(length, inspectLen(value, seen))
+	}
+	if err := in.reserve(1, stringSize(length)); err != nil {
+		return err
+	}
+
+	var out strings.Builder
+	next := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			out.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		if i == len(format) {
+			return newError("`%s` format string ends with %%", name)
+		}
+
+		verb := format[i]
+		if verb == '%' {
+			out.WriteByte('%')
+			continue
+		}
+
+		if next == len(values) {
+			return newError("not enough arguments for `%s` format string", name)
+		}
+		value := values[next]
+		next++
+
+		switch verb {
+		case 'd':
+			if value.Type() != object.INTEGER_OBJ {
+				return newError("`%s` verb %%d needs INTEGER, got %s", name, value.Type())
+			}
+		case 's':
+			if value.Type() != object.STRING_OBJ {
+				return newError("`%s` verb %%s needs STRING, got %s", name, value.Type())
+			}
+		case 'v':
+		default:
+			return newError("unknown `%s` verb %%%c", name, verb)
+		}
+
+		out.WriteString(value.Inspect())
+	}
+
+	if next != len(values) {
+		return newError("too many arguments for `%s` format string", name)
+	}
+
+	return &object.String{Value: out.String()}
+}
@@ -0,0 +1,69 @@
+package evaluator_test
+
+import "testing"
+
+func TestStringBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`split("a,b,,c", ",")`, `["a", "b", "", "c"]`},
+		{`split("  a b  c ")`, `["a", "b", "c"]`},
+		{`join(split("a-b", "-"), "+")`, `"a+b"`},
+		{`trim("  a b  ")`, `"a b"`},
+		{`trim("xxaxx", "x")`, `"a"`},
+		{`upper("abC")`, `"ABC"`},
+		{`lower("AbC")`, `"abc"`},
+		{`contains("monkey", "key")`, `true`},
+		{`contains("monkey", "donkey")`, `false`},
+		{`index_of("monkey", "key")`, `3`},
+		{`index_of("monkey", "x")`, `-1`},
+		{`replace("a-b-c", "-", "+")`, `"a+b+c"`},
+		{`starts_with("monkey", "mon")`, `true`},
+		{`ends_with("monkey", "mon")`, `false`},
+		{`repeat("ab", 3)`, `"ababab"`},
+		{`repeat("ab", 0)`, `""`},
+		{`repeat("", 9223372036854775807)`, `""`},
+		{`chars("héllo")`, `["h", "é", "l", "l", "o"]`},
+		{`chars("")`, `[]`},
+		{`ord("a")`, `97`},
+		{`ord("é")`, `233`},
+		{`chr(97)`, `"a"`},
+		{`format("%s is %d", "x", 5)`, `"x is 5"`},
+		{`format("%v and %v", [1], true)`, `"[
+1,
+] and true"`},
+		{`sprintf("100%%")`, `"100%"`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestStringBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`upper(1)`, "argument to `upper` must be STRING, got INTEGER"},
+		{`split()`, "wrong number of arguments. got=0, want=1 or 2"},
+		{`replace("a", "b", 1)`, "argument to `replace` must be STRING, got INTEGER"},
+		{`repeat("a", "b")`, "argument to `repeat` must be INTEGER, got STRING"},
+		{`repeat("a", -1)`, "argument to `repeat` must not be negative, got -1"},
+		{`repeat("ab", 4611686018427387904)`,
+			"`repeat` result is too long: 4611686018427387904 copies of 2 bytes"},
+		{`ord("ab")`, "argument to `ord` must be a single character, got \"ab\""},
+		{`chr(-1)`, "argument to `chr` out of range, got -1"},
+		{`format("%d", "a")`, "`format` verb %d needs INTEGER, got STRING"},
+		{`format("%s", 1)`, "`format` verb %s needs STRING, got INTEGER"},
+		{`format("%d %d", 1)`, "not enough arguments for `format` format string"},
+		{`sprintf("%d", 1, 2)`, "too many arguments for `sprintf` format string"},
+		{`format("%x", 1)`, "unknown `format` verb %x"},
+		{`format("50%")`, "`format` format string ends with %"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
@@ -0,0 +1,120 @@
+package evaluator
+
+import (
+	"math"
+	"monkeylang/object"
+	"time"
+)
+
+// Times are exchanged with scripts as Unix milliseconds and formatted in
+// UTC, using Go's reference-time layouts.
+const defaultTimeLayout = time.RFC3339
+
+// maxSleep is the longest `sleep`, in milliseconds, a time.Duration holds.
+const maxSleep = math.MaxInt64 / int64(time.Millisecond)
+
+func (in *Interpreter) timeBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"now": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newError("wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				return &object.Integer{Value: in.clock.Now().Unix()}
+			},
+		},
+
+		"now_ms": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newError("wrong number of arguments. got=%d, want=0",
+						len(args))
+				}
+
+				return &object.Integer{Value: in.clock.Now().UnixMilli()}
+			},
+		},
+
+		"sleep": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				values, err := integerArgs("sleep", args)
+				if err != nil {
+					return err
+				}
+				if values[0] < 0 {
+					return newError("argument to `sleep` must not be negative, got %d",
+						values[0])
+				}
+				if values[0] > maxSleep {
+					return newError("argument to `sleep` must be at most %d, got %d",
+						maxSleep, values[0])
+				}
+
+				if err := in.clock.Sleep(in.ctx, time.Duration(values[0])*time.Millisecond); err != nil {
+					return contextError(err)
+				}
+
+				return NULL
+			},
+		},
+
+		"format_time": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				values, err := integerArgs("format_time", args[:1])
+				if err != nil {
+					return err
+				}
+
+				layout := defaultTimeLayout
+				if len(args) == 2 {
+					strs, err := stringArgs("format_time", args[1:])
+					if err != nil {
+						return err
+					}
+					layout = strs[0]
+				}
+
+				t := time.UnixMilli(values[0]).UTC()
+				return in.charge(&object.String{Value: t.Format(layout)})
+			},
+		},
+
+		"parse_time": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				strs, err := stringArgs("parse_time", args)
+				if err != nil {
+					return err
+				}
+
+				layout := defaultTimeLayout
+				if len(strs) == 2 {
+					layout = strs[1]
+				}
+
+				t, parseErr := time.Parse(layout, strs[0])
+				if parseErr != nil {
+					return newError("parse_time: %s", parseErr)
+				}
+
+				return &object.Integer{Value: t.UnixMilli()}
+			},
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package evaluator_test
+
+import (
+	"context"
+	"monkeylang/evaluator"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func TestTimeBuiltins(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	interp := evaluator.New(evaluator.WithClock(&fakeClock{now: start}))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`now()`, `1709296200`},
+		{`now_ms()`, `1709296200000`},
+		{`let t = now_ms(); sleep(1500); now_ms() - t`, `1500`},
+		{`let t = now_ms(); sleep(9223372036854); now_ms() - t`, `9223372036854`},
+		{`format_time(0)`, `"1970-01-01T00:00:00Z"`},
+		{`format_time(1709296200000, "2006-01-02 15:04")`, `"2024-03-01 12:30"`},
+		{`parse_time("2024-03-01T12:30:00Z")`, `1709296200000`},
+		{`parse_time("01/03/2024", "02/01/2006")`, `1709251200000`},
+		{`parse_time(format_time(123000))`, `123000`},
+	}
+
+	for _, tt := range tests {
+		testEqualObjectIn(t, interp, tt.input, tt.expected)
+	}
+}
+
+func TestTimeBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`now(1)`, "wrong number of arguments. got=1, want=0"},
+		{`sleep(-1)`, "argument to `sleep` must not be negative, got -1"},
+		{`sleep("1")`, "argument to `sleep` must be INTEGER, got STRING"},
+		{`sleep(10000000000000)`, "argument to `sleep` must be at most 9223372036854, got 10000000000000"},
+		{`format_time(1, 2)`, "argument to `format_time` must be STRING, got INTEGER"},
+		{`parse_time("nope")`,
+			`parse_time: parsing time "nope" as "2006-01-02T15:04:05Z07:00": cannot parse "nope" as "2006"`},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
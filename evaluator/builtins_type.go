@@ -0,0 +1,140 @@
+package evaluator
+
+import (
+	"monkeylang/object"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (in *Interpreter) typeBuiltins() map[string]*object.Builtin {
+	return map[string]*object.Builtin{
+		"type": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				return in.charge(&object.String{Value: string(args[0].Type())})
+			},
+		},
+
+		"str": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				if str, ok := args[0].(*object.String); ok {
+					return str
+				}
+				if err := in.reserve(1, stringSize(inspectLen(args[0], map[object.Object]int{}))); err != nil {
+					return err
+				}
+
+				return &object.String{Value: args[0].Inspect()}
+			},
+		},
+
+		"int": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				switch arg := args[0].(type) {
+				case *object.Integer:
+					return arg
+				case *object.Boolean:
+					if arg.Value {
+						return &object.Integer{Value: 1}
+					}
+					return &object.Integer{Value: 0}
+				case *object.String:
+					return parseInteger(arg.Value, 10)
+				default:
+					return newError("argument to `int` not supported, got %s",
+						args[0].Type())
+				}
+			},
+		},
+
+		"bool": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				return nativeBoolToBooleanObject(isTruthy(args[0]))
+			},
+		},
+
+		"parse_int": {
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=1 or 2",
+						len(args))
+				}
+
+				str, ok := args[0].(*object.String)
+				if !ok {
+					return newError("argument to `parse_int` must be STRING, got %s",
+						args[0].Type())
+				}
+
+				base := int64(10)
+				if len(args) == 2 {
+					integer, ok := args[1].(*object.Integer)
+					if !ok {
+						return newError("argument to `parse_int` must be INTEGER, got %s",
+							args[1].Type())
+					}
+					base = integer.Value
+				}
+				if base < 2 || base > 36 {
+					return newError("base for `parse_int` must be between 2 and 36, got %d",
+						base)
+				}
+
+				return parseInteger(str.Value, int(base))
+			},
+		},
+
+		"is_int":    typePredicate(object.INTEGER_OBJ),
+		"is_string": typePredicate(object.STRING_OBJ),
+		"is_bool":   typePredicate(object.BOOLEAN_OBJ),
+		"is_null":   typePredicate(object.NULL_OBJ),
+		"is_array":  typePredicate(object.ARRAY_OBJ),
+		"is_hash":   typePredicate(object.HASH_OBJ),
+		"is_fn":     typePredicate(object.FUNCTION_OBJ, object.BUILTIN_OBJ),
+		"is_quote":  typePredicate(object.QUOTE_OBJ),
+	}
+}
+
+// typePredicate builds an `is_*` builtin that reports whether its argument
+// has one of the given types.
+func typePredicate(types ...object.ObjectType) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1",
+					len(args))
+			}
+
+			return nativeBoolToBooleanObject(slices.Contains(types, args[0].Type()))
+		},
+	}
+}
+
+func parseInteger(s string, base int) object.Object {
+	value, err := strconv.ParseInt(strings.TrimSpace(s), base, 64)
+	if err != nil {
+		return newError("could not parse %q as integer", s)
+	}
+
+	return &object.Integer{Value: value}
+}
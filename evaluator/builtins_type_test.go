@@ -0,0 +1,67 @@
+package evaluator_test
+
+import "testing"
+
+func TestTypeBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`type(1)`, `"INTEGER"`},
+		{`type("a")`, `"STRING"`},
+		{`type(true)`, `"BOOLEAN"`},
+		{`type([])`, `"ARRAY"`},
+		{`type({})`, `"HASH"`},
+		{`type(fn(x) { x })`, `"FUNCTION"`},
+		{`type(len)`, `"BUILTIN"`},
+		{`type(if (false) { 1 })`, `"NULL"`},
+		{`str(12)`, `"12"`},
+		{`str("a")`, `"a"`},
+		{`str(true)`, `"true"`},
+		{`int("42")`, `42`},
+		{`int(" -7 ")`, `-7`},
+		{`int(true)`, `1`},
+		{`int(5)`, `5`},
+		{`bool(0)`, `true`},
+		{`bool(if (false) { 1 })`, `false`},
+		{`bool(false)`, `false`},
+		{`parse_int("ff", 16)`, `255`},
+		{`parse_int("101", 2)`, `5`},
+		{`parse_int("10")`, `10`},
+		{`is_int(1)`, `true`},
+		{`is_int("1")`, `false`},
+		{`is_string("1")`, `true`},
+		{`is_bool(false)`, `true`},
+		{`is_null(if (false) { 1 })`, `true`},
+		{`is_array([1])`, `true`},
+		{`is_array({})`, `false`},
+		{`is_hash({})`, `true`},
+		{`is_fn(fn() { 1 })`, `true`},
+		{`is_fn(len)`, `true`},
+		{`is_fn(1)`, `false`},
+		{`is_quote(quote(1 + 2))`, `true`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestTypeBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`type()`, "wrong number of arguments. got=0, want=1"},
+		{`int("abc")`, "could not parse \"abc\" as integer"},
+		{`int([])`, "argument to `int` not supported, got ARRAY"},
+		{`parse_int(10)`, "argument to `parse_int` must be STRING, got INTEGER"},
+		{`parse_int("10", 1)`, "base for `parse_int` must be between 2 and 36, got 1"},
+		{`parse_int("z", 10)`, "could not parse \"z\" as integer"},
+		{`is_array(1, 2)`, "wrong number of arguments. got=2, want=1"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
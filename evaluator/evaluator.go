@@ -1,117 +1,137 @@
 package evaluator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"monkeylang/ast"
 	"monkeylang/object"
+	"monkeylang/token"
 )
 
 var (
-	NULL  = &object.Null{}
-	TRUE  = &object.Boolean{Value: true}
-	FALSE = &object.Boolean{Value: false}
+	NULL  = object.NULL
+	TRUE  = object.TRUE
+	FALSE = object.FALSE
 )
 
 func newError(format string, a ...any) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, a...)}
 }
 
+// setErrorPos records pos on obj if it is an error without a position.
+func setErrorPos(obj object.Object, pos token.Position) {
+	if err, ok := obj.(*object.Error); ok && !err.Pos.IsValid() {
+		err.Pos = pos
+	}
+}
+
+// isError reports whether obj aborts evaluation: either an error or a
+// request to exit.
 func isError(obj object.Object) bool {
-	return obj != nil && obj.Type() == object.ERROR_OBJ
+	return obj != nil &&
+		(obj.Type() == object.ERROR_OBJ || obj.Type() == object.EXIT_OBJ)
 }
 
+// Eval evaluates node in env using a new default interpreter. It is kept
+// for callers that predate Interpreter; like them, calls with separate
+// environments can run concurrently.
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	return New().eval(node, env)
+}
+
+func (in *Interpreter) eval(node ast.Node, env *object.Environment) object.Object {
+	if err := in.step(); err != nil {
+		return err
+	}
+
 	switch node := node.(type) {
 
 	case *ast.Program:
-		return evalProgram(node.Statements, env)
+		return in.evalProgram(node.Statements, env)
 	case *ast.ExpressionStatement:
-		return Eval(node.Expression, env)
+		return in.eval(node.Expression, env)
 	case *ast.BlockStatement:
-		return evalBlockStatement(node, env)
+		return in.evalBlockStatement(node, env)
 	case *ast.ReturnStatement:
-		val := Eval(node.ReturnValue, env)
+		val := in.eval(node.ReturnValue, env)
 		if isError(val) {
 			return val
 		}
 		return &object.ReturnValue{Value: val}
 	case *ast.LetStatement:
-		val := Eval(node.Value, env)
+		val := in.eval(node.Value, env)
 		if isError(val) {
 			return val
 		}
 		env.Set(node.Name.Value, val)
 
 	case *ast.IdentifierExpression:
-		return evalIdentifier(node, env)
+		result := in.evalIdentifier(node, env)
+		setErrorPos(result, node.Pos())
+		return result
 	case *ast.IntegerLiteral:
-		return &object.Integer{Value: node.Value}
+		return in.charge(&object.Integer{Value: node.Value})
 	case *ast.StringLiteral:
-		return &object.String{Value: node.Value}
+		return in.charge(&object.String{Value: node.Value})
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 	case *ast.UnaryExpression:
-		right := Eval(node.Right, env)
+		right := in.eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evalUnaryExpression(node.Operator, right)
+		result := evalUnaryExpression(node.Operator, right)
+		setErrorPos(result, node.Pos())
+		return result
 	case *ast.BinaryExpression:
-		left := Eval(node.Left, env)
+		left := in.eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
 
-		right := Eval(node.Right, env)
+		right := in.eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
 
-		return evalBinaryExpression(node.Operator, left, right)
+		result := evalBinaryExpression(node.Operator, left, right)
+		setErrorPos(result, node.Pos())
+		return in.charge(result)
 	case *ast.IfExpression:
-		return evalIfExpression(node, env)
+		return in.evalIfExpression(node, env)
 	case *ast.FunctionLiteral:
 		return &object.Function{
+			Name:       node.Name,
+			SelfBound:  node.SelfBound,
 			Parameters: node.Parameters,
 			Body:       node.Body,
 			Env:        env,
 		}
 	case *ast.CallExpression:
-		if node.Function.TokenLiteral() == "quote" {
-			return quote(node.Arguments[0], env)
-		}
-
-		function := Eval(node.Function, env)
-		if isError(function) {
-			return function
-		}
-
-		args, err := evalExpressions(node.Arguments, env)
-		if err != nil {
-			return err
-		}
-
-		return applyFunction(function, args)
+		return in.evalCallExpression(node, env, false)
 	case *ast.ArrayLiteral:
-		elements, err := evalExpressions(node.Elements, env)
+		elements, err := in.evalExpressions(node.Elements, env)
 		if err != nil {
 			return err
 		}
 
-		return &object.Array{Elements: elements}
+		return in.charge(&object.Array{Elements: elements})
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
+		left := in.eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
-		index := Eval(node.Index, env)
+		index := in.eval(node.Index, env)
 		if isError(index) {
 			return index
 		}
 
-		return evalIndexExpression(left, index)
+		result := evalIndexExpression(left, index)
+		setErrorPos(result, node.Pos())
+		return result
 	case *ast.HashLiteral:
-		return evalHashLiteral(node, env)
+		return in.evalHashLiteral(node, env)
 	}
 
 	return nil
@@ -126,11 +146,16 @@ func evalIndexExpression(left, index object.Object) object.Object {
 
 	if left.Type() == object.HASH_OBJ {
 		left := left.(*object.Hash)
-		hashable, ok := index.(object.Hashable)
-		if !ok {
+		if _, ok := object.HashKeyOf(index); !ok {
 			return newError("unusable as hash key: %s", index.Type())
 		}
-		return evalHashIndexExpression(left, hashable)
+		return evalHashIndexExpression(left, index)
+	}
+
+	if left.Type() == object.MODULE_OBJ && index.Type() == object.STRING_OBJ {
+		left := left.(*object.Module)
+		index := index.(*object.String)
+		return evalModuleIndexExpression(left, index)
 	}
 
 	return newError("index operator not supported: %s", left.Type())
@@ -145,25 +170,34 @@ func evalArrayIndexExpression(array *object.Array, index *object.Integer) object
 
 }
 
-func evalHashIndexExpression(hash *object.Hash, index object.Hashable) object.Object {
-	hashPair, ok := hash.Pairs[index.HashKey()]
+func evalHashIndexExpression(hash *object.Hash, index object.Object) object.Object {
+	value, ok := hash.Get(index)
 	if !ok {
 		return NULL
 	}
 
-	return hashPair.Value
+	return value
+}
+
+func evalModuleIndexExpression(module *object.Module, index *object.String) object.Object {
+	member, ok := module.Members[index.Value]
+	if !ok {
+		return newError("module %s has no member %s", module.Name, index.Value)
+	}
+
+	return member
 }
 
-func evalExpressions(
+func (in *Interpreter) evalExpressions(
 	exprs []ast.Expression,
 	env *object.Environment,
-) ([]object.Object, *object.Error) {
+) ([]object.Object, object.Object) {
 	var result []object.Object
 
 	for i := range len(exprs) {
-		evaluated := Eval(exprs[i], env)
+		evaluated := in.eval(exprs[i], env)
 		if isError(evaluated) {
-			return nil, evaluated.(*object.Error)
+			return nil, evaluated
 		}
 		result = append(result, evaluated)
 	}
@@ -171,16 +205,16 @@ func evalExpressions(
 	return result, nil
 }
 
-func evalProgram(stmts []ast.Statement, env *object.Environment) object.Object {
+func (in *Interpreter) evalProgram(stmts []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
 	for i := range len(stmts) {
-		result = Eval(stmts[i], env)
+		result = in.eval(stmts[i], env)
 
 		switch result := result.(type) {
 		case *object.ReturnValue:
 			return result.Value
-		case *object.Error:
+		case *object.Error, *object.Exit:
 			return result
 		}
 	}
@@ -188,14 +222,14 @@ func evalProgram(stmts []ast.Statement, env *object.Environment) object.Object {
 	return result
 }
 
-func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+func (in *Interpreter) evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
 	var result object.Object
 
 	for i := range len(block.Statements) {
-		result = Eval(block.Statements[i], env)
+		result = in.eval(block.Statements[i], env)
 
 		switch result := result.(type) {
-		case *object.ReturnValue, *object.Error:
+		case *object.ReturnValue, *object.Error, *object.Exit:
 			return result
 		}
 	}
@@ -254,6 +288,11 @@ func evalBinaryExpression(op string, left, right object.Object) object.Object {
 		return evalIntegerBinaryExpression(op, left, right)
 	}
 
+	if left.Type() == object.ARRAY_OBJ && right.Type() == object.ARRAY_OBJ &&
+		(op == "<" || op == ">") {
+		return evalComparison(op, left, right)
+	}
+
 	if op == "==" {
 		return nativeBoolToBooleanObject(left == right)
 	}
@@ -305,6 +344,10 @@ func evalStringBinaryExpression(op string, left, right *object.String) object.Ob
 	switch op {
 	case "+":
 		return &object.String{Value: leftVal + rightVal}
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
 	case "==":
 		return nativeBoolToBooleanObject(leftVal == rightVal)
 	case "!=":
@@ -315,18 +358,42 @@ func evalStringBinaryExpression(op string, left, right *object.String) object.Ob
 	}
 }
 
-func evalIfExpression(node *ast.IfExpression, env *object.Environment) object.Object {
-	cond := Eval(node.Condition, env)
+func evalComparison(op string, left, right object.Object) object.Object {
+	comparable, ok := left.(object.Comparable)
+	if !ok {
+		return newError("unknown operator: %s %s %s",
+			left.Type(), op, right.Type())
+	}
+
+	result, ok := comparable.Compare(right)
+	if !ok {
+		return newError("unknown operator: %s %s %s",
+			left.Type(), op, right.Type())
+	}
+
+	switch op {
+	case "<":
+		return nativeBoolToBooleanObject(result < 0)
+	case ">":
+		return nativeBoolToBooleanObject(result > 0)
+	default:
+		return newError("unknown operator: %s %s %s",
+			left.Type(), op, right.Type())
+	}
+}
+
+func (in *Interpreter) evalIfExpression(node *ast.IfExpression, env *object.Environment) object.Object {
+	cond := in.eval(node.Condition, env)
 	if isError(cond) {
 		return cond
 	}
 
 	if isTruthy(cond) {
-		return Eval(node.ThenBranch, env)
+		return in.eval(node.ThenBranch, env)
 	}
 
 	if node.ElseBranch != nil {
-		return Eval(node.ElseBranch, env)
+		return in.eval(node.ElseBranch, env)
 	}
 
 	return NULL
@@ -341,7 +408,7 @@ func isTruthy(obj object.Object) bool {
 	}
 }
 
-func evalIdentifier(
+func (in *Interpreter) evalIdentifier(
 	node *ast.IdentifierExpression,
 	env *object.Environment,
 ) object.Object {
@@ -350,42 +417,193 @@ func evalIdentifier(
 		return val
 	}
 
-	builtin, ok := builtins[node.Value]
+	builtin, ok := in.builtins[node.Value]
 	if ok {
 		return builtin
 	}
 
+	module, ok := in.modules[node.Value]
+	if ok {
+		return module
+	}
+
 	return newError("identifier not found: %s", node.Value)
 }
 
-func applyFunction(
+// evalCallExpression calls the function node names. In tail position a
+// call to a Monkey function is not made but returned as a tailCall.
+func (in *Interpreter) evalCallExpression(
+	node *ast.CallExpression,
+	env *object.Environment,
+	tail bool,
+) object.Object {
+	if node.Function.TokenLiteral() == "quote" {
+		return in.quote(node.Arguments[0], env)
+	}
+
+	function := in.eval(node.Function, env)
+	if isError(function) {
+		return function
+	}
+
+	args, err := in.evalExpressions(node.Arguments, env)
+	if err != nil {
+		return err
+	}
+
+	frame := object.Frame{Function: calleeName(node.Function), Pos: node.Pos()}
+
+	if fn, ok := function.(*object.Function); ok && tail {
+		return &tailCall{fn: fn, args: args, frame: frame}
+	}
+
+	result := in.callFunction(function, args, frame)
+	if _, ok := function.(*object.Builtin); ok {
+		setErrorPos(result, node.Pos())
+	}
+
+	return result
+}
+
+// functionFrame names frame after fn, falling back to the name fn was
+// called by when fn is anonymous.
+func functionFrame(fn *object.Function, frame object.Frame) object.Frame {
+	if fn.Name != "" {
+		frame.Function = fn.Name
+	}
+
+	return frame
+}
+
+func arityError(fn *object.Function, got int) *object.Error {
+	if fn.Name == "" {
+		return newError("wrong number of arguments. got=%d, want=%d",
+			got, len(fn.Parameters))
+	}
+
+	return newError("wrong number of arguments to `%s`. got=%d, want=%d",
+		fn.Name, got, len(fn.Parameters))
+}
+
+// calleeName returns the name a function is called by, or "" when the
+// callee is not an identifier.
+func calleeName(node ast.Expression) string {
+	if ident, ok := node.(*ast.IdentifierExpression); ok {
+		return ident.Value
+	}
+
+	return ""
+}
+
+// applyFunction calls obj on behalf of a builtin or the embedding program,
+// where there is no call site in the source.
+func (in *Interpreter) applyFunction(
+	obj object.Object,
+	args []object.Object,
+) object.Object {
+	return in.callFunction(obj, args, object.Frame{})
+}
+
+// callFunction calls obj with args. Errors coming out of a Monkey function
+// get frame added to their stack; when the function ended in tail calls,
+// the frame of the last one is added first.
+func (in *Interpreter) callFunction(
 	obj object.Object,
 	args []object.Object,
+	frame object.Frame,
 ) object.Object {
+	if err := in.ctx.Err(); err != nil {
+		return contextError(err)
+	}
+
 	switch obj := obj.(type) {
 	case *object.Builtin:
 		return obj.Fn(args...)
 
 	case *object.Function:
-		extendedEnv := extendFunctionEnv(obj, args)
-		evaluated := Eval(obj.Body, extendedEnv)
+		if max := in.limits.MaxCallDepth; max > 0 {
+			if in.depth >= max {
+				return limitError(object.DEPTH_LIMIT_ERROR, "maximum call depth of %d exceeded", max)
+			}
+
+			in.depth++
+			defer func() { in.depth-- }()
+		}
 
-		return unwrapReturnValue(evaluated)
+		// Tail calls come back as a tailCall and replace the current
+		// call, so they run in constant Go stack space.
+		frame = functionFrame(obj, frame)
+		current := frame
+		for {
+			if len(args) != len(obj.Parameters) {
+				err := arityError(obj, len(args))
+				err.Pos = current.Pos
+				return err
+			}
+
+			extendedEnv := extendFunctionEnv(obj, args)
+			evaluated := unwrapReturnValue(in.evalTail(obj.Body, extendedEnv, true))
+
+			call, ok := evaluated.(*tailCall)
+			if !ok {
+				if isError(evaluated) {
+					addFrame(evaluated, current)
+					if current != frame {
+						addFrame(evaluated, frame)
+					}
+				}
+				return evaluated
+			}
+
+			if err := in.ctx.Err(); err != nil {
+				return contextError(err)
+			}
+			obj, args = call.fn, call.args
+			current = functionFrame(obj, call.frame)
+		}
 	default:
 		return newError("not a function: %s", obj.Type())
 	}
 }
 
+// maxStackFrames bounds the stack recorded on an error, so that runaway
+// recursion does not build an enormous traceback.
+const maxStackFrames = 64
+
+// addFrame records frame on the stack of obj if it is an error.
+func addFrame(obj object.Object, frame object.Frame) {
+	if err, ok := obj.(*object.Error); ok && len(err.Stack) < maxStackFrames {
+		err.Stack = append(err.Stack, frame)
+	}
+}
+
+// contextError reports that evaluation stopped because its context was
+// canceled or its deadline passed.
+func contextError(err error) *object.Error {
+	kind := object.CANCELED_ERROR
+	if errors.Is(err, context.DeadlineExceeded) {
+		kind = object.TIMEOUT_ERROR
+	}
+
+	return &object.Error{Message: "evaluation stopped: " + err.Error(), Kind: kind}
+}
+
 func extendFunctionEnv(
 	fn *object.Function,
 	args []object.Object,
 ) *object.Environment {
 	env := object.NewEnclosedEnv(fn.Env)
 
+	// A function declared as `fn name(...)` can call itself even where
+	// its name is bound to something else, or not bound at all. A name
+	// taken from `let` is looked up like any other, so rebinding it
+	// changes what the body calls.
+	if fn.SelfBound {
+		env.Set(fn.Name, fn)
+	}
+
 	for i := range len(fn.Parameters) {
-		if i < len(args) {
-			env.Set(fn.Parameters[i].Value, args[i])
-		}
+		env.Set(fn.Parameters[i].Value, args[i])
 	}
 
 	return env
@@ -399,30 +617,29 @@ func unwrapReturnValue(obj object.Object) object.Object {
 	return obj
 }
 
-func evalHashLiteral(
+func (in *Interpreter) evalHashLiteral(
 	node *ast.HashLiteral,
 	env *object.Environment,
 ) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := object.NewHash()
 
-	for keyNode, valueNode := range node.Pairs {
-		key := Eval(keyNode, env)
+	for _, pair := range node.Pairs {
+		key := in.eval(pair.Key, env)
 		if isError(key) {
 			return key
 		}
 
-		hashKey, ok := key.(object.Hashable)
-		if !ok {
+		if _, ok := object.HashKeyOf(key); !ok {
 			return newError("unusable as hash key: %s", key.Type())
 		}
 
-		value := Eval(valueNode, env)
+		value := in.eval(pair.Value, env)
 		if isError(value) {
 			return value
 		}
 
-		pairs[hashKey.HashKey()] = object.HashPair{Key: key, Value: value}
+		hash.Set(key, value)
 	}
 
-	return &object.Hash{Pairs: pairs}
+	return in.charge(hash)
 }
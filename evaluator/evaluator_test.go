@@ -1,6 +1,7 @@
 package evaluator_test
 
 import (
+	"bytes"
 	"monkeylang/evaluator"
 	"monkeylang/lexer"
 	"monkeylang/object"
@@ -45,6 +46,63 @@ func testEval(input string) object.Object {
 	return evaluator.Eval(program, env)
 }
 
+// testEvalIn evaluates input in the global environment of interp.
+func testEvalIn(interp *evaluator.Interpreter, input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+
+	return interp.Eval(program)
+}
+
+// testEqualObject evaluates input and checks that the result equals the
+// value of the expected Monkey expression.
+func testEqualObject(t *testing.T, input, expected string) bool {
+	t.Helper()
+
+	return testEqualObjectIn(t, nil, input, expected)
+}
+
+// testEqualObjectIn is testEqualObject for a configured interpreter. A nil
+// interp evaluates input in a fresh environment.
+func testEqualObjectIn(t *testing.T, interp *evaluator.Interpreter, input, expected string) bool {
+	t.Helper()
+
+	var evaluated object.Object
+	if interp == nil {
+		evaluated = testEval(input)
+	} else {
+		evaluated = testEvalIn(interp, input)
+	}
+
+	want := testEval(expected)
+	if !object.Equal(evaluated, want) {
+		t.Errorf("%s wrong. got=%s, want=%s",
+			input, evaluated.Inspect(), want.Inspect())
+		return false
+	}
+
+	return true
+}
+
+func testErrorObject(t *testing.T, obj object.Object, expected string) bool {
+	t.Helper()
+
+	errObj, ok := obj.(*object.Error)
+	if !ok {
+		t.Errorf("object is not Error. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if errObj.Message != expected {
+		t.Errorf("wrong error message. expected=%q, got=%q",
+			expected, errObj.Message)
+		return false
+	}
+
+	return true
+}
+
 func testIntegerObject(t *testing.T, o object.Object, expected int64) bool {
 	result, ok := o.(*object.Integer)
 	if !ok {
@@ -89,6 +147,15 @@ func TestEvalBooleanExpression(t *testing.T) {
 		{"(1 > 2) == false", true},
 		{`"string" =="string"`, true},
 		{`"string" =="sting"`, false},
+		{`"a" < "b"`, true},
+		{`"b" < "a"`, false},
+		{`"abc" > "ab"`, true},
+		{`"" < "a"`, true},
+		{"[1, 2] < [1, 3]", true},
+		{"[1, 2] > [1, 3]", false},
+		{"[1, 2] < [1, 2, 0]", true},
+		{"[] < [1]", true},
+		{`[["a"], 2] > [["a"], 1]`, true},
 	}
 
 	for _, tt := range tests {
@@ -242,10 +309,22 @@ if (10 > 1) {
 			`"Hello" - "World"`,
 			"unknown operator: STRING - STRING",
 		},
+		{
+			`[1] < ["a"]`,
+			"unknown operator: ARRAY < ARRAY",
+		},
+		{
+			`[fn(x) { x }] < [1]`,
+			"unknown operator: ARRAY < ARRAY",
+		},
 		{
 			`{"name": "Monkey"}[fn(x) { x }];`,
 			"unusable as hash key: FUNCTION",
 		},
+		{
+			`{[fn(x) { x }]: 1}`,
+			"unusable as hash key: ARRAY",
+		},
 	}
 
 	for _, tt := range tests {
@@ -321,6 +400,65 @@ func TestFunctionApplication(t *testing.T) {
 	}
 }
 
+func TestNamedFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let f = fn f(n) { if (n == 0) { 0 } else { n + f(n - 1) } }; let g = f; let f = 0; g(3)`, `6`},
+		{`map([3, 4], fn fact(n) { if (n == 0) { 1 } else { n * fact(n - 1) } })`, `[6, 24]`},
+		{`let f = fn g(n) { if (n == 0) { "g" } else { g(n - 1) } }; f(2)`, `"g"`},
+		{`let fact = 1; let f = fn fact(n) { fact }; f(0) == f`, `true`},
+		{`let f = fn(f) { f }; f(1)`, `1`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+
+	// A name taken from `let` is not bound inside the function, so
+	// rebinding it wraps recursive calls too.
+	var out bytes.Buffer
+	interp := evaluator.New(evaluator.WithStdout(&out))
+	_, err := interp.EvalString(`
+	let count = fn(n) { if (n > 0) { count(n - 1) } };
+	let orig = count;
+	let count = fn(n) { puts("wrapped " + str(n)); orig(n) };
+	count(3)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "wrapped 3\nwrapped 2\nwrapped 1\nwrapped 0\n" {
+		t.Errorf("rebinding did not wrap recursive calls. got=%q", out.String())
+	}
+
+	evaluated := testEval(`let add = fn(a, b) { a + b }; add`)
+	if evaluated.Inspect() != "fn add(a, b) {\n(a + b)\n}" {
+		t.Errorf("wrong Inspect. got=%q", evaluated.Inspect())
+	}
+}
+
+func TestFunctionArity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let add = fn(a, b) { a + b }; add(1)`, "wrong number of arguments to `add`. got=1, want=2"},
+		{`fn(a) { a }(1, 2)`, "wrong number of arguments. got=2, want=1"},
+		{`let f = fn(n) { g(n, n) }; let g = fn(a) { a }; f(1)`, "wrong number of arguments to `g`. got=2, want=1"},
+		{`map([1], fn() { 1 })`, "wrong number of arguments. got=1, want=0"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+
+	err := testEval("let add = fn(a, b) { a + b };\nadd(1)").(*object.Error)
+	if err.Inspect() != "Error: wrong number of arguments to `add`. got=1, want=2 at 2:1" {
+		t.Errorf("wrong error. got=%q", err.Inspect())
+	}
+}
+
 func TestStringExpressions(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -352,6 +490,8 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`len("")`, 0},
 		{`len("four")`, 4},
 		{`len("hello world")`, 11},
+		{`len({})`, 0},
+		{`len({"a": 1, "b": 2})`, 2},
 		{`len(1)`, "argument to `len` not supported, got INTEGER"},
 		{`len("one", "two")`, "wrong number of arguments. got=2, want=1"},
 	}
@@ -470,25 +610,42 @@ let two = "two";
 		t.Fatalf("Eval didn't return Hash. got=%T (%+v)", evaluated, evaluated)
 	}
 
-	expected := map[object.HashKey]int64{
-		(&object.String{Value: "one"}).HashKey():   1,
-		(&object.String{Value: "two"}).HashKey():   2,
-		(&object.String{Value: "three"}).HashKey(): 3,
-		(&object.Integer{Value: 4}).HashKey():      4,
-		evaluator.TRUE.HashKey():                   5,
-		evaluator.FALSE.HashKey():                  6,
+	expected := []struct {
+		key   object.Object
+		value int64
+	}{
+		{&object.String{Value: "one"}, 1},
+		{&object.String{Value: "two"}, 2},
+		{&object.String{Value: "three"}, 3},
+		{&object.Integer{Value: 4}, 4},
+		{evaluator.TRUE, 5},
+		{evaluator.FALSE, 6},
 	}
 
-	if len(result.Pairs) != len(expected) {
-		t.Fatalf("Hash has wrong num of pairs. got=%d", len(result.Pairs))
+	if result.Len() != len(expected) {
+		t.Fatalf("Hash has wrong num of pairs. got=%d", result.Len())
 	}
 
-	for expectedKey, expectedValue := range expected {
-		pair, ok := result.Pairs[expectedKey]
+	for _, tt := range expected {
+		value, ok := result.Get(tt.key)
 		if !ok {
 			t.Errorf("no pair for given key in Pairs")
+			continue
+		}
+		testIntegerObject(t, value, tt.value)
+	}
+}
+
+func TestHashLiteralOrder(t *testing.T) {
+	input := `{"z": 1, "a": 2, 3: 3, "m": 4, true: 5}`
+	expected := "{z: 1, a: 2, 3: 3, m: 4, true: 5}"
+
+	for range 10 {
+		evaluated := testEval(input)
+		if evaluated.Inspect() != expected {
+			t.Fatalf("hash out of order. got=%q, want=%q",
+				evaluated.Inspect(), expected)
 		}
-		testIntegerObject(t, pair.Value, expectedValue)
 	}
 }
 
@@ -536,3 +693,57 @@ func TestHashIndexExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorStack(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`let x = 1 + true`,
+			"Error: type mismatch: INTEGER + BOOLEAN at 1:9",
+		},
+		{
+			"let add = fn(a, b) { a + b };\nlet f = fn(x) { let y = add(x, true); y };\nf(1)",
+			"Error: type mismatch: INTEGER + BOOLEAN at 1:22\n" +
+				"  in add, called at 2:25\n" +
+				"  in f, called at 3:1",
+		},
+		{
+			"let f = fn() { missing };\n[1, f()]",
+			"Error: identifier not found: missing at 1:16\n" +
+				"  in f, called at 2:5",
+		},
+		{
+			"let bad = fn(x) { x[\"a\"] };\nmap([1], fn(x) { 1 + bad(x) })",
+			"Error: index operator not supported: INTEGER at 1:19\n" +
+				"  in bad, called at 2:22\n" +
+				"  in <anonymous>",
+		},
+		{
+			"let apply = fn(g) { 1 + g(1) };\napply(fn named(x) { x + true })",
+			"Error: type mismatch: INTEGER + BOOLEAN at 2:21\n" +
+				"  in named, called at 1:25\n" +
+				"  in apply, called at 2:1",
+		},
+		{
+			"let g = fn(x) { -x };\nlet f = fn(x) { g(x) };\n1 + f(true)",
+			"Error: unknown operator: -BOOLEAN at 1:17\n" +
+				"  in g, called at 2:17\n" +
+				"  in f, called at 3:5",
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Traceback() != tt.expected {
+			t.Errorf("wrong traceback.\ngot:\n%s\nwant:\n%s", errObj.Traceback(), tt.expected)
+		}
+	}
+}
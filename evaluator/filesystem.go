@@ -0,0 +1,186 @@
+package evaluator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileSystem is what the file builtins go through. Reads use the fs.FS
+// methods, so names are slash-separated and relative to the filesystem's
+// root; the remaining methods change files.
+type FileSystem interface {
+	fs.FS
+	WriteFile(name string, data []byte) error
+	AppendFile(name string, data []byte) error
+	Remove(name string) error
+}
+
+var (
+	errFileSystemDisabled = errors.New("filesystem access is disabled")
+	errReadOnly           = errors.New("filesystem is read-only")
+	errOutsideRoot        = errors.New("path is outside the filesystem root")
+)
+
+type dirFS struct {
+	fs.FS
+	root string
+}
+
+// DirFS returns a FileSystem rooted at dir. Names that are not valid
+// fs.FS paths, such as absolute paths or ones containing "..", are
+// rejected. Symbolic links are followed only as long as they stay inside
+// dir; a name that resolves outside it is rejected too.
+func DirFS(dir string) FileSystem {
+	return &dirFS{FS: os.DirFS(dir), root: dir}
+}
+
+// Open opens name once it is known to resolve inside the root. The file
+// itself is opened through os.DirFS, so errors mention name rather than
+// the host path.
+func (d *dirFS) Open(name string) (fs.File, error) {
+	if _, err := d.path("open", name); err != nil {
+		return nil, err
+	}
+
+	return d.FS.Open(name)
+}
+
+// path returns the host path name resolves to, following symbolic links,
+// or an error if that is outside the root. A name that does not exist yet
+// resolves to its base name inside its resolved parent directory, where
+// writing creates it.
+func (d *dirFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	root, err := filepath.EvalSymlinks(d.root)
+	if err != nil {
+		return "", &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	path := filepath.Join(root, filepath.FromSlash(name))
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		resolved, err = d.parentPath(root, path)
+	}
+	if err != nil {
+		return "", &fs.PathError{Op: op, Path: name, Err: unwrapPathError(err)}
+	}
+
+	if !within(root, resolved) {
+		return "", &fs.PathError{Op: op, Path: name, Err: errOutsideRoot}
+	}
+
+	return resolved, nil
+}
+
+// parentPath resolves the directory of path, which is under root, and
+// joins the base name of path to it, without following a final symbolic
+// link.
+func (d *dirFS) parentPath(root, path string) (string, error) {
+	if path == root {
+		return root, nil
+	}
+
+	// A dangling link would be followed by a write and create its target,
+	// which may be anywhere.
+	if info, err := os.Lstat(path); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+		return "", errOutsideRoot
+	}
+
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filepath.Base(path)), nil
+}
+
+// within reports whether path is root or inside it.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// unwrapPathError strips the host path from err, so that errors report
+// the name the program used.
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+
+	return err
+}
+
+func (d *dirFS) WriteFile(name string, data []byte) error {
+	path, err := d.path("write", name)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (d *dirFS) AppendFile(name string, data []byte) error {
+	path, err := d.path("append", name)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func (d *dirFS) Remove(name string) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrPermission}
+	}
+
+	// Removing a symbolic link does not touch its target, so only the
+	// directory holding it has to be inside the root.
+	dir, err := d.path("remove", path.Dir(name))
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: unwrapPathError(err)}
+	}
+
+	return os.Remove(filepath.Join(dir, path.Base(name)))
+}
+
+type readOnlyFS struct {
+	fs.FS
+}
+
+// ReadOnly wraps fsys in a FileSystem whose write operations all fail.
+func ReadOnly(fsys fs.FS) FileSystem {
+	return readOnlyFS{FS: fsys}
+}
+
+func (readOnlyFS) WriteFile(name string, data []byte) error {
+	return &fs.PathError{Op: "write", Path: name, Err: errReadOnly}
+}
+
+func (readOnlyFS) AppendFile(name string, data []byte) error {
+	return &fs.PathError{Op: "append", Path: name, Err: errReadOnly}
+}
+
+func (readOnlyFS) Remove(name string) error {
+	return &fs.PathError{Op: "remove", Path: name, Err: errReadOnly}
+}
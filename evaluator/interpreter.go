@@ -0,0 +1,326 @@
+package evaluator
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"maps"
+	"math/rand"
+	"monkeylang/ast"
+	"monkeylang/lexer"
+	"monkeylang/object"
+	"monkeylang/parser"
+	"monkeylang/resolver"
+	"os"
+	"strings"
+	"time"
+)
+
+// Limits bound the resources a program may use. A zero field means no
+// limit. Steps and allocations are counted from the start of each call to
+// Eval, EvalString, EvalContext, EvalFile or Call; exceeding a limit stops
+// evaluation with an error of the matching object.ErrorKind.
+type Limits struct {
+	// MaxSteps caps the number of AST nodes evaluated.
+	MaxSteps int
+	// MaxCallDepth caps the number of nested function calls.
+	MaxCallDepth int
+	// MaxObjects and MaxBytes cap the number and approximate total size
+	// of the arrays, hashes, strings and integers created by literals and
+	// operators, and of the arrays, hashes and strings created by
+	// builtins. Builtins are stopped before they allocate. Values a
+	// builtin returns without creating them, such as the element `first`
+	// returns, are not counted again. Memory freed during evaluation is
+	// not given back.
+	MaxObjects int
+	MaxBytes   int
+}
+
+// usage is what the current evaluation has consumed of its Limits.
+type usage struct {
+	steps   int
+	objects int
+	bytes   int
+}
+
+// Interpreter evaluates Monkey programs. Each interpreter has its own
+// global environment, macro environment, builtins, I/O streams,
+// filesystem, clock and random source, so differently configured
+// interpreters can run in the same process. An Interpreter is not safe for
+// concurrent use.
+type Interpreter struct {
+	env      *object.Environment
+	macroEnv *object.Environment
+	builtins map[string]*object.Builtin
+	modules  map[string]*object.Module
+
+	stdin      *bufio.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+	filesystem FileSystem
+	clock      Clock
+	rng        *rand.Rand
+	regexps    *regexpCache
+
+	ctx     context.Context
+	limits  Limits
+	usage   usage
+	depth   int
+	running int
+
+	allowed     []string
+	diagnostics func([]resolver.Diagnostic)
+}
+
+type Option func(*Interpreter)
+
+// WithBuiltins restricts the interpreter to the named builtins and
+// modules. Names that are not builtins are ignored.
+func WithBuiltins(names ...string) Option {
+	return func(in *Interpreter) { in.allowed = names }
+}
+
+// WithStdin makes `read_line` and `read_all` read from r. Passing a
+// *bufio.Reader lets the caller share its buffer with the builtins.
+func WithStdin(r io.Reader) Option {
+	return func(in *Interpreter) { in.stdin = bufio.NewReader(r) }
+}
+
+// WithStdout sends the output of `puts` and `print` to w.
+func WithStdout(w io.Writer) Option {
+	return func(in *Interpreter) { in.stdout = w }
+}
+
+// WithStderr sends the output of `eprint` to w.
+func WithStderr(w io.Writer) Option {
+	return func(in *Interpreter) { in.stderr = w }
+}
+
+// WithFileSystem routes the file builtins through fsys. Without it file
+// access is disabled.
+func WithFileSystem(fsys FileSystem) Option {
+	return func(in *Interpreter) { in.filesystem = fsys }
+}
+
+// WithClock makes the time builtins use c instead of the system clock.
+func WithClock(c Clock) Option {
+	return func(in *Interpreter) { in.clock = c }
+}
+
+// WithSeed seeds the random builtins so that runs are reproducible.
+func WithSeed(seed int64) Option {
+	return func(in *Interpreter) { in.rng = rand.New(rand.NewSource(seed)) }
+}
+
+// WithLimits bounds the resources programs run by the interpreter may use.
+func WithLimits(limits Limits) Option {
+	return func(in *Interpreter) { in.limits = limits }
+}
+
+// WithDiagnostics makes EvalString, EvalContext and EvalFile check each
+// program with the resolver once its macros are expanded, and pass what it
+// finds to report before evaluating that same program. Evaluation goes
+// ahead whatever report is given.
+func WithDiagnostics(report func([]resolver.Diagnostic)) Option {
+	return func(in *Interpreter) { in.diagnostics = report }
+}
+
+// WithMacroEnv makes the interpreter define and look up macros in env, so
+// macros can be shared between interpreters.
+func WithMacroEnv(env *object.Environment) Option {
+	return func(in *Interpreter) { in.macroEnv = env }
+}
+
+// New returns an interpreter with a fresh global environment, every
+// builtin, the process's standard streams, the system clock and no
+// filesystem access, adjusted by opts.
+func New(opts ...Option) *Interpreter {
+	in := &Interpreter{
+		env:      object.NewEnvironment(),
+		macroEnv: object.NewEnvironment(),
+		stdin:    bufio.NewReader(os.Stdin),
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+		clock:    systemClock{},
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		regexps:  newRegexpCache(),
+		ctx:      context.Background(),
+	}
+
+	for _, opt := range opts {
+		opt(in)
+	}
+
+	in.builtins = in.newBuiltins()
+	in.modules = maps.Clone(modules)
+
+	if in.allowed != nil {
+		allowed := make(map[string]bool, len(in.allowed))
+		for _, name := range in.allowed {
+			allowed[name] = true
+		}
+
+		maps.DeleteFunc(in.builtins, func(name string, _ *object.Builtin) bool {
+			return !allowed[name]
+		})
+		maps.DeleteFunc(in.modules, func(name string, _ *object.Module) bool {
+			return !allowed[name]
+		})
+	}
+
+	return in
+}
+
+// Env returns the global environment programs are evaluated in.
+func (in *Interpreter) Env() *object.Environment {
+	return in.env
+}
+
+// Eval evaluates node in the interpreter's global environment. Runtime
+// errors are returned as *object.Error values.
+func (in *Interpreter) Eval(node ast.Node) object.Object {
+	return in.run(func() object.Object { return in.eval(node, in.env) })
+}
+
+// run performs one top-level evaluation. Usage is counted against the
+// limits from zero, unless f runs inside another evaluation, as when a
+// registered Go func calls back into the interpreter.
+func (in *Interpreter) run(f func() object.Object) object.Object {
+	if in.running == 0 {
+		in.usage = usage{}
+	}
+
+	in.running++
+	defer func() { in.running-- }()
+
+	return f()
+}
+
+// ParseError lists the problems the parser found in a program.
+type ParseError struct {
+	Errors []string
+}
+
+func (e *ParseError) Error() string {
+	return "parser errors:\n\t" + strings.Join(e.Errors, "\n\t")
+}
+
+// EvalString parses input, expands its macros and evaluates it in the
+// interpreter's global environment. Parse failures are returned as a
+// *ParseError and runtime errors as the *object.Error itself.
+func (in *Interpreter) EvalString(input string) (object.Object, error) {
+	return in.EvalContext(context.Background(), input)
+}
+
+// EvalContext is EvalString that stops once ctx is done. Evaluation checks
+// ctx on every function call, including the callbacks of builtins such as
+// `map`, and while `sleep` waits, and stops with an *object.Error of kind
+// CANCELED_ERROR or TIMEOUT_ERROR.
+func (in *Interpreter) EvalContext(ctx context.Context, input string) (object.Object, error) {
+	prev := in.ctx
+	in.ctx = ctx
+	defer func() { in.ctx = prev }()
+
+	p := parser.New(lexer.New(input))
+
+	program := p.ParseProgram()
+	if len(p.Errors) != 0 {
+		return nil, &ParseError{Errors: p.Errors}
+	}
+
+	DefineMacros(program, in.macroEnv)
+	expanded := in.expandMacros(program, in.macroEnv)
+
+	if in.diagnostics != nil {
+		in.diagnostics(in.resolve(expanded.(*ast.Program)))
+	}
+
+	result := in.Eval(expanded)
+	if err, ok := result.(*object.Error); ok {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// EvalFile reads the program at path from the host filesystem and
+// evaluates it like EvalString.
+func (in *Interpreter) EvalFile(path string) (object.Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return in.EvalString(string(data))
+}
+
+// Check parses input, expands its macros and reports what the resolver
+// finds in it, without evaluating it. Names bound in the global
+// environment count as defined, and macros defined by input are not kept.
+// Parse failures are returned as a *ParseError.
+//
+// Expanding macros runs their bodies, so checking a program and then
+// evaluating it runs them twice; WithDiagnostics checks the program that
+// is evaluated instead.
+func (in *Interpreter) Check(input string) ([]resolver.Diagnostic, error) {
+	p := parser.New(lexer.New(input))
+
+	program := p.ParseProgram()
+	if len(p.Errors) != 0 {
+		return nil, &ParseError{Errors: p.Errors}
+	}
+
+	macroEnv := object.NewEnclosedEnv(in.macroEnv)
+	DefineMacros(program, macroEnv)
+	expanded := in.expandMacros(program, macroEnv)
+
+	return in.resolve(expanded.(*ast.Program)), nil
+}
+
+// resolve checks program against the interpreter's builtins, modules and
+// global environment.
+func (in *Interpreter) resolve(program *ast.Program) []resolver.Diagnostic {
+	globals := resolver.Globals{Defined: in.env.Names()}
+	for name := range in.builtins {
+		globals.Builtins = append(globals.Builtins, name)
+	}
+	for name := range in.modules {
+		globals.Builtins = append(globals.Builtins, name)
+	}
+
+	return resolver.Resolve(program, globals)
+}
+
+// Call invokes fn, a Monkey function or builtin, with args and returns its
+// result. A runtime error, including fn not being callable, is returned as
+// the *object.Error itself.
+func (in *Interpreter) Call(fn object.Object, args ...object.Object) (object.Object, error) {
+	result := in.run(func() object.Object { return in.applyFunction(fn, args) })
+	if err, ok := result.(*object.Error); ok {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (in *Interpreter) newBuiltins() map[string]*object.Builtin {
+	all := in.coreBuiltins()
+
+	for _, group := range []map[string]*object.Builtin{
+		in.hashBuiltins(),
+		in.arrayBuiltins(),
+		in.stringBuiltins(),
+		in.typeBuiltins(),
+		in.jsonBuiltins(),
+		in.regexpBuiltins(),
+		in.ioBuiltins(),
+		in.fileBuiltins(),
+		in.timeBuiltins(),
+		in.randomBuiltins(),
+		in.assertBuiltins(),
+	} {
+		maps.Copy(all, group)
+	}
+
+	return all
+}
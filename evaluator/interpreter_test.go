@@ -0,0 +1,316 @@
+package evaluator_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"monkeylang/evaluator"
+	"monkeylang/lexer"
+	"monkeylang/object"
+	"monkeylang/parser"
+	"monkeylang/resolver"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInterpretersAreIndependent(t *testing.T) {
+	var outA, outB bytes.Buffer
+	a := evaluator.New(evaluator.WithStdout(&outA))
+	b := evaluator.New(evaluator.WithStdout(&outB))
+
+	testEvalIn(a, `let x = 1; puts("a")`)
+	testEvalIn(b, `let x = 2; puts("b")`)
+
+	testEqualObjectIn(t, a, `x`, `1`)
+	testEqualObjectIn(t, b, `x`, `2`)
+
+	if outA.String() != "a\n" || outB.String() != "b\n" {
+		t.Errorf("output mixed up. a=%q, b=%q", outA.String(), outB.String())
+	}
+}
+
+// TestEvalConcurrently is only meaningful under the race detector.
+func TestEvalConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			p := parser.New(lexer.New(`let f = fn(n) { if (n == 0) { rand_int(0, 10) } else { f(n - 1) } }; f(20)`))
+			result := evaluator.Eval(p.ParseProgram(), object.NewEnvironment())
+			if _, ok := result.(*object.Integer); !ok {
+				t.Errorf("object is not Integer. got=%T (%+v)", result, result)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithBuiltins(t *testing.T) {
+	interp := evaluator.New(evaluator.WithBuiltins("len", "math"))
+
+	testEqualObjectIn(t, interp, `len("abc") + math.abs(-1)`, `4`)
+	testErrorObject(t, testEvalIn(interp, `puts(1)`), "identifier not found: puts")
+	testErrorObject(t, testEvalIn(interp, `strings.upper("a")`), "identifier not found: strings")
+}
+
+func TestEvalString(t *testing.T) {
+	interp := evaluator.New()
+
+	result, err := interp.EvalString(`let add = fn(a, b) { a + b }; add(1, 2)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 3)
+
+	result, err = interp.EvalString(`add(2, 2)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 4)
+
+	_, err = interp.EvalString(`let = 1`)
+	var parseErr *evaluator.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("error is not ParseError. got=%T (%v)", err, err)
+	}
+	if len(parseErr.Errors) == 0 {
+		t.Errorf("ParseError has no errors")
+	}
+
+	_, err = interp.EvalString(`1 + true`)
+	var runtimeErr *object.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Fatalf("error is not *object.Error. got=%T (%v)", err, err)
+	}
+	if runtimeErr.Message != "type mismatch: INTEGER + BOOLEAN" {
+		t.Errorf("wrong error message. got=%q", runtimeErr.Message)
+	}
+}
+
+func TestEvalStringExpandsMacros(t *testing.T) {
+	macros := object.NewEnvironment()
+	first := evaluator.New(evaluator.WithMacroEnv(macros))
+	second := evaluator.New(evaluator.WithMacroEnv(macros))
+
+	_, err := first.EvalString(`let unless = macro(c, a, b) { quote(if (!(unquote(c))) { unquote(a) } else { unquote(b) }) };`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := second.EvalString(`unless(10 > 5, 1, 2)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 2)
+}
+
+func TestEvalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.monkey")
+	os.WriteFile(path, []byte("let double = fn(x) { x * 2 };\ndouble(21)\n"), 0o644)
+
+	result, err := evaluator.New().EvalFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 42)
+
+	if _, err := evaluator.New().EvalFile(path + ".missing"); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	interp := evaluator.New(evaluator.WithLimits(evaluator.Limits{MaxCallDepth: 10}))
+
+	input := `let count = fn(n) { if (n == 0) { 0 } else { 1 + count(n - 1) } };`
+	testEqualObjectIn(t, interp, input+`count(9)`, `9`)
+	testErrorObject(t, testEvalIn(interp, input+`count(10)`), "maximum call depth of 10 exceeded")
+	testEqualObjectIn(t, interp, `count(5)`, `5`)
+}
+
+func TestCall(t *testing.T) {
+	interp := evaluator.New()
+
+	handlers, err := interp.EvalString(`
+		let add = fn(a, b) { a + b };
+		let check = fn(x) { if (x < 0) { return "negative"; } assert(x != 0, "zero"); "ok" };
+		[add, check, len]
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fns := handlers.(*object.Array).Elements
+
+	result, err := interp.Call(fns[0], &object.Integer{Value: 1}, &object.Integer{Value: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 3)
+
+	result, err = interp.Call(fns[1], &object.Integer{Value: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if str, ok := result.(*object.String); !ok || str.Value != "negative" {
+		t.Errorf("return value not unwrapped. got=%s", result.Inspect())
+	}
+
+	result, err = interp.Call(fns[2], &object.String{Value: "four"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 4)
+
+	_, err = interp.Call(fns[1], &object.Integer{Value: 0})
+	if err == nil || err.(*object.Error).Message != "assertion failed: zero" {
+		t.Errorf("wrong error. got=%v", err)
+	}
+
+	_, err = interp.Call(&object.Integer{Value: 1})
+	if err == nil || err.(*object.Error).Message != "not a function: INTEGER" {
+		t.Errorf("wrong error. got=%v", err)
+	}
+}
+
+func TestEvalContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	interp := evaluator.New()
+	_, err := interp.EvalContext(canceled, `let f = fn() { 1 }; f()`)
+	testContextError(t, err, object.CANCELED_ERROR, "evaluation stopped: context canceled")
+
+	_, err = interp.EvalContext(canceled, `len(range(100000))`)
+	testContextError(t, err, object.CANCELED_ERROR, "evaluation stopped: context canceled")
+
+	result, err := interp.EvalString(`f()`)
+	if err != nil {
+		t.Fatalf("interpreter still canceled: %v", err)
+	}
+	testIntegerObject(t, result, 1)
+
+	timeout, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = interp.EvalContext(timeout, `let forever = fn() { forever() }; forever()`)
+	testContextError(t, err, object.TIMEOUT_ERROR, "evaluation stopped: context deadline exceeded")
+}
+
+func TestEvalContextStopsSleep(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := evaluator.New().EvalContext(ctx, `sleep(2000)`)
+	testContextError(t, err, object.TIMEOUT_ERROR, "evaluation stopped: context deadline exceeded")
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("sleep outlived its deadline. took %s", elapsed)
+	}
+}
+
+func TestEvalContextStopsBuiltinCallbacks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out bytes.Buffer
+	interp := evaluator.New(evaluator.WithStdout(&out))
+	interp.Register("cancel", cancel)
+
+	_, err := interp.EvalContext(ctx, `map([1, 2, 3], fn(x) { puts(x); if (x == 2) { cancel() } })`)
+	testContextError(t, err, object.CANCELED_ERROR, "evaluation stopped: context canceled")
+
+	if out.String() != "1\n2\n" {
+		t.Errorf("callbacks ran after cancel. got=%q", out.String())
+	}
+}
+
+func testContextError(t *testing.T, err error, kind object.ErrorKind, message string) {
+	t.Helper()
+
+	errObj, ok := err.(*object.Error)
+	if !ok {
+		t.Fatalf("error is not *object.Error. got=%T (%v)", err, err)
+	}
+	if errObj.Kind != kind {
+		t.Errorf("wrong error kind. got=%q, want=%q", errObj.Kind, kind)
+	}
+	if errObj.Message != message {
+		t.Errorf("wrong error message. got=%q, want=%q", errObj.Message, message)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	interp := evaluator.New(evaluator.WithBuiltins("len"))
+	interp.Register("lookup", func(key string) string { return key })
+
+	if _, err := interp.EvalString(`let known = 1; let twice = macro(x) { quote(unquote(x) + unquote(x)) };`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	diagnostics, err := interp.Check(`twice(len(lookup("a")) + known + puts)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	expected := []string{"1:34: error: identifier not found: puts"}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("wrong diagnostics. got=%q, want=%q", got, expected)
+	}
+
+	diagnostics, _ = interp.Check(`let square = macro(x) { quote(unquote(x) * unquote(x)) }; square(2)`)
+	if len(diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diagnostics)
+	}
+	if _, err := interp.EvalString(`square(2)`); err == nil {
+		t.Errorf("Check kept a macro definition")
+	}
+
+	_, err = interp.Check(`let = 1`)
+	var parseErr *evaluator.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("error is not ParseError. got=%T (%v)", err, err)
+	}
+}
+
+func TestWithDiagnostics(t *testing.T) {
+	var out bytes.Buffer
+	var got []string
+	interp := evaluator.New(
+		evaluator.WithStdout(&out),
+		evaluator.WithDiagnostics(func(diagnostics []resolver.Diagnostic) {
+			for _, d := range diagnostics {
+				got = append(got, d.String())
+			}
+		}),
+	)
+
+	// The macro body prints when it runs, so each expansion shows up in out.
+	result, err := interp.EvalString(`
+let twice = macro(x) { puts("expanded"); quote(unquote(x) + unquote(x)) };
+let f = fn() { missing };
+twice(2)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testIntegerObject(t, result, 4)
+
+	if out.String() != "expanded\n" {
+		t.Errorf("macro body ran more than once. got=%q", out.String())
+	}
+
+	expected := []string{"3:16: error: identifier not found: missing"}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("wrong diagnostics. got=%q, want=%q", got, expected)
+	}
+}
@@ -0,0 +1,160 @@
+package evaluator
+
+import (
+	"io"
+	"math"
+	"monkeylang/object"
+)
+
+// Rough sizes in bytes used to account for allocations. They only need to
+// grow with the real cost, not match it.
+const (
+	objectSize  = 16
+	elementSize = 8
+	pairSize    = 48
+)
+
+func limitError(kind object.ErrorKind, format string, a ...any) *object.Error {
+	err := newError(format, a...)
+	err.Kind = kind
+	return err
+}
+
+// step counts the evaluation of one node against the step limit.
+func (in *Interpreter) step() *object.Error {
+	if max := in.limits.MaxSteps; max > 0 {
+		in.usage.steps++
+		if in.usage.steps > max {
+			return limitError(object.STEP_LIMIT_ERROR, "step limit of %d exceeded", max)
+		}
+	}
+
+	return nil
+}
+
+// reserve counts objects and bytes against the allocation limits before
+// they are allocated, so that a limit stops an allocation instead of
+// reporting it once it is done. Builtins call it with the size of the
+// arrays, hashes and strings they are about to build.
+func (in *Interpreter) reserve(objects, bytes int) *object.Error {
+	if in.limits.MaxObjects == 0 && in.limits.MaxBytes == 0 {
+		return nil
+	}
+
+	in.usage.objects = addSize(in.usage.objects, objects)
+	in.usage.bytes = addSize(in.usage.bytes, bytes)
+
+	if max := in.limits.MaxObjects; max > 0 && in.usage.objects > max {
+		return limitError(object.OBJECT_LIMIT_ERROR, "object limit of %d exceeded", max)
+	}
+	if max := in.limits.MaxBytes; max > 0 && in.usage.bytes > max {
+		return limitError(object.MEMORY_LIMIT_ERROR, "memory limit of %d bytes exceeded", max)
+	}
+
+	return nil
+}
+
+// charge counts obj, which was just created, against the allocation
+// limits. It returns obj, or a limit error once the evaluation has
+// allocated too much.
+func (in *Interpreter) charge(obj object.Object) object.Object {
+	if err := in.reserve(sizeOf(obj)); err != nil {
+		return err
+	}
+
+	return obj
+}
+
+// sizeOf approximates what creating obj itself allocated. The elements of
+// arrays and hashes are counted when they are created, so containers
+// built from existing values do not pay for them again.
+func sizeOf(obj object.Object) (objects, bytes int) {
+	switch obj := obj.(type) {
+	case nil, *object.Error, *object.Exit, *object.Boolean, *object.Null:
+		return 0, 0
+	case *object.String:
+		return 1, stringSize(len(obj.Value))
+	case *object.Array:
+		return 1, arraySize(len(obj.Elements))
+	case *object.Hash:
+		return 1, hashSize(obj.Len())
+	}
+
+	return 1, objectSize
+}
+
+// inspectLen approximates the length of obj.Inspect() without building
+// it, so that printing a value which shares one array many times over can
+// be stopped before it is printed. Each array and hash is measured once.
+func inspectLen(obj object.Object, seen map[object.Object]int) int {
+	if n, ok := seen[obj]; ok {
+		return n
+	}
+
+	var n int
+	switch obj := obj.(type) {
+	case *object.String:
+		return len(obj.Value)
+	case *object.Array:
+		n = len("[\n]")
+		for _, el := range obj.Elements {
+			n = addSize(n, addSize(inspectLen(el, seen), len(",\n")))
+		}
+	case *object.Hash:
+		n = len("{}")
+		for _, pair := range obj.Pairs() {
+			n = addSize(n, addSize(inspectLen(pair.Key, seen), inspectLen(pair.Value, seen)))
+			n = addSize(n, len(": , "))
+		}
+	default:
+		return len(obj.Inspect())
+	}
+
+	seen[obj] = n
+	return n
+}
+
+// readAll reads r to the end, reserving what it reads against the
+// allocation limits as it goes. A limit being exceeded is returned as the
+// *object.Error itself.
+func (in *Interpreter) readAll(r io.Reader) ([]byte, error) {
+	return io.ReadAll(&reservingReader{in: in, r: r})
+}
+
+type reservingReader struct {
+	in *Interpreter
+	r  io.Reader
+}
+
+func (r *reservingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if limitErr := r.in.reserve(0, n); limitErr != nil {
+		return 0, limitErr
+	}
+
+	return n, err
+}
+
+// stringSize, arraySize and hashSize approximate the size of a string of
+// n bytes, an array of n elements and a hash of n pairs.
+func stringSize(n int) int { return addSize(objectSize, n) }
+func arraySize(n int) int  { return addSize(objectSize, mulSize(n, elementSize)) }
+func hashSize(n int) int   { return addSize(objectSize, mulSize(n, pairSize)) }
+
+// addSize and mulSize combine non-negative sizes, saturating instead of
+// overflowing, so that absurd requests still exceed the limits.
+func addSize(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+
+	return a + b
+}
+
+func mulSize(n, size int) int {
+	if size != 0 && n > math.MaxInt/size {
+		return math.MaxInt
+	}
+
+	return n * size
+}
@@ -0,0 +1,182 @@
+package evaluator_test
+
+import (
+	"monkeylang/evaluator"
+	"monkeylang/object"
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	countdown := `let count = fn(n) { if (n == 0) { 0 } else { 1 + count(n - 1) } };`
+	grow := `let grow = fn(a, n) { if (n == 0) { a } else { grow(concat(a, a), n - 1) } };`
+
+	tests := []struct {
+		limits   evaluator.Limits
+		input    string
+		kind     object.ErrorKind
+		expected string
+	}{
+		{
+			evaluator.Limits{MaxSteps: 100},
+			countdown + `count(100)`,
+			object.STEP_LIMIT_ERROR,
+			"step limit of 100 exceeded",
+		},
+		{
+			evaluator.Limits{MaxCallDepth: 50},
+			countdown + `count(100)`,
+			object.DEPTH_LIMIT_ERROR,
+			"maximum call depth of 50 exceeded",
+		},
+		{
+			evaluator.Limits{MaxCallDepth: 50},
+			countdown + `assert_error(fn() { count(100) }); 1`,
+			object.DEPTH_LIMIT_ERROR,
+			"maximum call depth of 50 exceeded",
+		},
+		{
+			evaluator.Limits{MaxSteps: 100},
+			countdown + `assert_error(fn() { count(100) }); 1`,
+			object.STEP_LIMIT_ERROR,
+			"step limit of 100 exceeded",
+		},
+		{
+			evaluator.Limits{MaxObjects: 1000},
+			`len(range(2000))`,
+			object.OBJECT_LIMIT_ERROR,
+			"object limit of 1000 exceeded",
+		},
+		{
+			evaluator.Limits{MaxBytes: 1 << 16},
+			grow + `grow([1], 30)`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 65536 bytes exceeded",
+		},
+		{
+			evaluator.Limits{MaxBytes: 1 << 16},
+			`let double = fn(s, n) { if (n == 0) { s } else { double(s + s, n - 1) } }; double("ab", 20)`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 65536 bytes exceeded",
+		},
+		{
+			evaluator.Limits{MaxBytes: 1 << 10},
+			`let h = {}; let h = set(set(set(h, 1, 1), 2, 2), 3, 3); repeat("x", 2000)`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 1024 bytes exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		interp := evaluator.New(evaluator.WithLimits(tt.limits))
+
+		_, err := interp.EvalString(tt.input)
+		errObj, ok := err.(*object.Error)
+		if !ok {
+			t.Errorf("%q: error is not *object.Error. got=%T (%v)", tt.input, err, err)
+			continue
+		}
+		if errObj.Kind != tt.kind {
+			t.Errorf("%q: wrong error kind. got=%q, want=%q", tt.input, errObj.Kind, tt.kind)
+		}
+		if errObj.Message != tt.expected {
+			t.Errorf("%q: wrong error message. got=%q, want=%q", tt.input, errObj.Message, tt.expected)
+		}
+	}
+}
+
+func TestLimitsStopBuiltinsBeforeAllocating(t *testing.T) {
+	limits := evaluator.Limits{MaxObjects: 10000, MaxBytes: 1 << 20}
+
+	tests := []struct {
+		input    string
+		kind     object.ErrorKind
+		expected string
+	}{
+		{`len(range(20000000))`, object.OBJECT_LIMIT_ERROR, "object limit of 10000 exceeded"},
+		{`len(repeat("ab", 1099511627776))`, object.MEMORY_LIMIT_ERROR, "memory limit of 1048576 bytes exceeded"},
+		{`let a = range(4000); let b = concat(a, a, a, a, a, a, a, a); len(concat(b, b, b, b, b, b, b, b))`, object.MEMORY_LIMIT_ERROR, "memory limit of 1048576 bytes exceeded"},
+		{`len(split(repeat("a,", 100000), ","))`, object.OBJECT_LIMIT_ERROR, "object limit of 10000 exceeded"},
+		{
+			`let double = fn(a, n) { if (n == 0) { a } else { double([a, a], n - 1) } }; len(json_encode(double("x", 40)))`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 1048576 bytes exceeded",
+		},
+		{
+			`let double = fn(a, n) { if (n == 0) { a } else { double([a, a], n - 1) } }; len(str(double("x", 40)))`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 1048576 bytes exceeded",
+		},
+		{
+			`let big = range(5000); let loop = fn(n) { if (n == 0) { 0 } else { push(big, n); loop(n - 1) } }; loop(2000)`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 1048576 bytes exceeded",
+		},
+		{
+			`let big = range(5000); let loop = fn(n) { if (n == 0) { 0 } else { map(big, fn(x) { x }); loop(n - 1) } }; loop(2000)`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 1048576 bytes exceeded",
+		},
+		{
+			`let h = {"a": repeat("x", 1000)}; let loop = fn(n) { if (n == 0) { 0 } else { values(set(h, n, 1)); loop(n - 1) } }; loop(20000)`,
+			object.OBJECT_LIMIT_ERROR,
+			"object limit of 10000 exceeded",
+		},
+		{
+			`len(replace(repeat("a", 1000), "a", repeat("b", 2000)))`,
+			object.MEMORY_LIMIT_ERROR,
+			"memory limit of 1048576 bytes exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		interp := evaluator.New(evaluator.WithLimits(limits))
+
+		_, err := interp.EvalString(tt.input)
+		testContextError(t, err, tt.kind, tt.expected)
+	}
+
+	interp := evaluator.New(
+		evaluator.WithLimits(limits),
+		evaluator.WithStdin(strings.NewReader(strings.Repeat("x", 1<<21))),
+	)
+	_, err := interp.EvalString(`len(read_all())`)
+	testContextError(t, err, object.MEMORY_LIMIT_ERROR, "memory limit of 1048576 bytes exceeded")
+}
+
+func TestLimitsCountOnlyNewValues(t *testing.T) {
+	interp := evaluator.New(evaluator.WithLimits(evaluator.Limits{
+		MaxObjects: 2000,
+		MaxBytes:   1 << 16,
+	}))
+
+	// Each call returns existing values, which cost nothing again.
+	input := `
+	let cfg = {"items": range(500), "name": repeat("x", 10000)};
+	let loop = fn(n) {
+		if (n == 0) { return 0; }
+		get(cfg, "items"); first(cfg["items"]); str(cfg["name"]);
+		loop(n - 1)
+	};
+	loop(100)`
+
+	if _, err := interp.EvalString(input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLimitsResetPerEvaluation(t *testing.T) {
+	interp := evaluator.New(evaluator.WithLimits(evaluator.Limits{
+		MaxSteps:   500,
+		MaxObjects: 100,
+		MaxBytes:   4096,
+	}))
+
+	for range 10 {
+		result, err := interp.EvalString(`let s = "abc" + "def"; len(range(50)) + len(s)`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		testIntegerObject(t, result, 56)
+	}
+}
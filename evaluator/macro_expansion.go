@@ -45,7 +45,13 @@ func addMacro(stmt ast.Statement, env *object.Environment) {
 	env.Set(letStmt.Name.Value, macro)
 }
 
+// ExpandMacros expands the macro calls in program using a new default
+// interpreter.
 func ExpandMacros(program *ast.Program, env *object.Environment) ast.Node {
+	return New().expandMacros(program, env)
+}
+
+func (in *Interpreter) expandMacros(program *ast.Program, env *object.Environment) ast.Node {
 	return ast.Modify(program, func(node ast.Node) ast.Node {
 		callExpr, ok := node.(*ast.CallExpression)
 		if !ok {
@@ -59,7 +65,7 @@ func ExpandMacros(program *ast.Program, env *object.Environment) ast.Node {
 
 		args := quoteArgs(callExpr)
 		evalEnv := extendMacroEnv(macro, args)
-		evaluated := Eval(macro.Body, evalEnv)
+		evaluated := in.eval(macro.Body, evalEnv)
 
 		quote, ok := evaluated.(*object.Quote)
 		if !ok {
@@ -0,0 +1,364 @@
+package evaluator
+
+import (
+	"math"
+	"math/bits"
+	"monkeylang/object"
+	"slices"
+)
+
+var mathModule = &object.Module{
+	Name: "math",
+	Members: map[string]object.Object{
+		"abs": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				values, err := integerArgs("math.abs", args)
+				if err != nil {
+					return err
+				}
+				if values[0] == math.MinInt64 {
+					return newError("`math.abs(%d)` overflows INTEGER", values[0])
+				}
+
+				return &object.Integer{Value: abs(values[0])}
+			},
+		},
+
+		"min": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				values, err := integerListArgs("math.min", args)
+				if err != nil {
+					return err
+				}
+
+				return &object.Integer{Value: slices.Min(values)}
+			},
+		},
+
+		"max": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				values, err := integerListArgs("math.max", args)
+				if err != nil {
+					return err
+				}
+
+				return &object.Integer{Value: slices.Max(values)}
+			},
+		},
+
+		"pow": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				values, err := integerArgs("math.pow", args)
+				if err != nil {
+					return err
+				}
+
+				base, exp := values[0], values[1]
+				if exp < 0 {
+					return newError("exponent to `math.pow` must not be negative, got %d", exp)
+				}
+
+				// base is only squared while exp has bits left, so an
+				// overflow always means the result overflows.
+				result, ok := int64(1), true
+				for exp > 0 && ok {
+					if exp&1 == 1 {
+						result, ok = mulInt(result, base)
+					}
+					if exp >>= 1; exp > 0 && ok {
+						base, ok = mulInt(base, base)
+					}
+				}
+				if !ok {
+					return newError("`math.pow(%d, %d)` overflows INTEGER", values[0], values[1])
+				}
+
+				return &object.Integer{Value: result}
+			},
+		},
+
+		"sqrt": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				values, err := integerArgs("math.sqrt", args)
+				if err != nil {
+					return err
+				}
+				if values[0] < 0 {
+					return newError("argument to `math.sqrt` must not be negative, got %d",
+						values[0])
+				}
+
+				return &object.Integer{Value: isqrt(values[0])}
+			},
+		},
+
+		"floor": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				return evalDivision("math.floor", args, func(q, r, d int64) int64 {
+					if r != 0 && (r < 0) != (d < 0) {
+						return q - 1
+					}
+					return q
+				})
+			},
+		},
+
+		"ceil": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				return evalDivision("math.ceil", args, func(q, r, d int64) int64 {
+					if r != 0 && (r < 0) == (d < 0) {
+						return q + 1
+					}
+					return q
+				})
+			},
+		},
+
+		"round": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				return evalDivision("math.round", args, func(q, r, d int64) int64 {
+					if 2*absUint(r) < absUint(d) {
+						return q
+					}
+					if (r < 0) != (d < 0) {
+						return q - 1
+					}
+					return q + 1
+				})
+			},
+		},
+
+		"clamp": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 3 {
+					return newError("wrong number of arguments. got=%d, want=3",
+						len(args))
+				}
+
+				values, err := integerArgs("math.clamp", args)
+				if err != nil {
+					return err
+				}
+
+				value, lo, hi := values[0], values[1], values[2]
+				if lo > hi {
+					return newError("bounds to `math.clamp` are reversed, got %d > %d", lo, hi)
+				}
+
+				return &object.Integer{Value: min(max(value, lo), hi)}
+			},
+		},
+
+		"sum": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newError("wrong number of arguments. got=%d, want=1",
+						len(args))
+				}
+
+				arr, ok := args[0].(*object.Array)
+				if !ok {
+					return newError("argument to `math.sum` must be ARRAY, got %s",
+						args[0].Type())
+				}
+
+				values, err := integerArgs("math.sum", arr.Elements)
+				if err != nil {
+					return err
+				}
+
+				var total int64
+				for _, value := range values {
+					sum := total + value
+					if (value > 0 && sum < total) || (value < 0 && sum > total) {
+						return newError("`math.sum` overflows INTEGER")
+					}
+					total = sum
+				}
+
+				return &object.Integer{Value: total}
+			},
+		},
+
+		"gcd": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				values, err := integerArgs("math.gcd", args)
+				if err != nil {
+					return err
+				}
+
+				g := gcd(values[0], values[1])
+				if g > math.MaxInt64 {
+					return newError("`math.gcd(%d, %d)` overflows INTEGER", values[0], values[1])
+				}
+
+				return &object.Integer{Value: int64(g)}
+			},
+		},
+
+		"lcm": &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newError("wrong number of arguments. got=%d, want=2",
+						len(args))
+				}
+
+				values, err := integerArgs("math.lcm", args)
+				if err != nil {
+					return err
+				}
+
+				a, b := values[0], values[1]
+				if a == 0 || b == 0 {
+					return &object.Integer{Value: 0}
+				}
+
+				hi, lcm := bits.Mul64(absUint(a)/gcd(a, b), absUint(b))
+				if hi != 0 || lcm > math.MaxInt64 {
+					return newError("`math.lcm(%d, %d)` overflows INTEGER", a, b)
+				}
+
+				return &object.Integer{Value: int64(lcm)}
+			},
+		},
+	},
+}
+
+// integerArgs checks that every argument is an INTEGER and returns their
+// values.
+func integerArgs(name string, args []object.Object) ([]int64, *object.Error) {
+	values := make([]int64, len(args))
+	for i, arg := range args {
+		integer, ok := arg.(*object.Integer)
+		if !ok {
+			return nil, newError("argument to `%s` must be INTEGER, got %s",
+				name, arg.Type())
+		}
+		values[i] = integer.Value
+	}
+
+	return values, nil
+}
+
+// integerListArgs accepts either a single ARRAY of integers or one or more
+// INTEGER arguments.
+func integerListArgs(name string, args []object.Object) ([]int64, *object.Error) {
+	if len(args) == 1 {
+		if arr, ok := args[0].(*object.Array); ok {
+			args = arr.Elements
+		}
+	}
+
+	if len(args) == 0 {
+		return nil, newError("`%s` of no values", name)
+	}
+
+	return integerArgs(name, args)
+}
+
+// evalDivision implements the rounding builtins. With one argument the
+// integer is returned as is; with two it is divided by the second and
+// rounded by the given function of quotient, remainder and divisor.
+func evalDivision(
+	name string,
+	args []object.Object,
+	round func(q, r, d int64) int64,
+) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=1 or 2",
+			len(args))
+	}
+
+	values, err := integerArgs(name, args)
+	if err != nil {
+		return err
+	}
+	if len(values) == 1 {
+		return &object.Integer{Value: values[0]}
+	}
+
+	n, d := values[0], values[1]
+	if d == 0 {
+		return newError("division by zero in `%s`", name)
+	}
+	if n == math.MinInt64 && d == -1 {
+		return newError("`%s(%d, %d)` overflows INTEGER", name, n, d)
+	}
+
+	return &object.Integer{Value: round(n/d, n%d, d)}
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// absUint returns the magnitude of n, which for math.MinInt64 does not
+// fit in an int64.
+func absUint(n int64) uint64 {
+	if n < 0 {
+		return -uint64(n)
+	}
+	return uint64(n)
+}
+
+// mulInt returns a*b, reporting false if it overflows.
+func mulInt(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(absUint(a), absUint(b))
+	if (a < 0) != (b < 0) {
+		if hi != 0 || lo > 1<<63 {
+			return 0, false
+		}
+		return int64(-lo), true
+	}
+
+	if hi != 0 || lo > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(lo), true
+}
+
+// gcd returns the greatest common divisor of a and b, which is 1<<63 when
+// both are math.MinInt64 or one is and the other is 0.
+func gcd(a, b int64) uint64 {
+	x, y := absUint(a), absUint(b)
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return x
+}
+
+func isqrt(n int64) int64 {
+	lo, hi := int64(0), min(n, 3037000499)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if mid*mid <= n {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
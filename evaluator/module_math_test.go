@@ -0,0 +1,98 @@
+package evaluator_test
+
+import "testing"
+
+func TestMathModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`math.abs(-5)`, `5`},
+		{`math.abs(5)`, `5`},
+		{`math.min(3, 1, 2)`, `1`},
+		{`math.min([3, 1, 2])`, `1`},
+		{`math.max(3, 1, 2)`, `3`},
+		{`math.max([4])`, `4`},
+		{`math.pow(2, 10)`, `1024`},
+		{`math.pow(-3, 3)`, `-27`},
+		{`math.pow(5, 0)`, `1`},
+		{`math.sqrt(16)`, `4`},
+		{`math.sqrt(17)`, `4`},
+		{`math.sqrt(0)`, `0`},
+		{`math.floor(7)`, `7`},
+		{`math.floor(7, 2)`, `3`},
+		{`math.floor(-7, 2)`, `-4`},
+		{`math.ceil(7, 2)`, `4`},
+		{`math.ceil(-7, 2)`, `-3`},
+		{`math.ceil(6, 2)`, `3`},
+		{`math.round(7, 2)`, `4`},
+		{`math.round(7, 3)`, `2`},
+		{`math.round(-7, 2)`, `-4`},
+		{`math.clamp(15, 0, 10)`, `10`},
+		{`math.clamp(-5, 0, 10)`, `0`},
+		{`math.clamp(5, 0, 10)`, `5`},
+		{`math.sum([1, 2, 3])`, `6`},
+		{`math.sum([])`, `0`},
+		{`math.gcd(12, 18)`, `6`},
+		{`math.gcd(-4, 6)`, `2`},
+		{`math.lcm(4, 6)`, `12`},
+		{`math.lcm(0, 6)`, `0`},
+		{`math.lcm(-4, 6)`, `12`},
+		{`math.lcm(9223372036854775807, 1)`, `9223372036854775807`},
+		{`math.pow(2, 62)`, `4611686018427387904`},
+		{`math.pow(-2, 63)`, `-9223372036854775807 - 1`},
+		{`math.pow(3037000499, 2)`, `9223372030926249001`},
+		{`math.pow(1, 9223372036854775807)`, `1`},
+		{`math.pow(-1, 9223372036854775807)`, `-1`},
+		{`math.pow(0, 100)`, `0`},
+		{`math.abs(-9223372036854775807)`, `9223372036854775807`},
+		{`math.round(1, -9223372036854775807 - 1)`, `0`},
+		{`math.round(-9223372036854775807 - 1, 2)`, `-4611686018427387904`},
+		{`math.floor(-9223372036854775807 - 1, 1)`, `-9223372036854775807 - 1`},
+		{`math.gcd(-9223372036854775807 - 1, 2)`, `2`},
+		{`math.sum([9223372036854775807, -1, 1])`, `9223372036854775807`},
+		{`math["abs"](-1)`, `1`},
+		{`let m = math; m.max(1, 2)`, `2`},
+		{`type(math)`, `"MODULE"`},
+		{`{"a": {"b": 2}}.a.b`, `2`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+}
+
+func TestMathModuleErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`math.abs("a")`, "argument to `math.abs` must be INTEGER, got STRING"},
+		{`math.abs()`, "wrong number of arguments. got=0, want=1"},
+		{`math.min()`, "`math.min` of no values"},
+		{`math.max([])`, "`math.max` of no values"},
+		{`math.max(1, true)`, "argument to `math.max` must be INTEGER, got BOOLEAN"},
+		{`math.pow(2, -1)`, "exponent to `math.pow` must not be negative, got -1"},
+		{`math.sqrt(-4)`, "argument to `math.sqrt` must not be negative, got -4"},
+		{`math.floor(1, 0)`, "division by zero in `math.floor`"},
+		{`math.clamp(1, 5, 0)`, "bounds to `math.clamp` are reversed, got 5 > 0"},
+		{`math.sum([1, "a"])`, "argument to `math.sum` must be INTEGER, got STRING"},
+		{`math.sum(1)`, "argument to `math.sum` must be ARRAY, got INTEGER"},
+		{`math.nope`, "module math has no member nope"},
+		{`math.pow(10, 19)`, "`math.pow(10, 19)` overflows INTEGER"},
+		{`math.pow(2, 64)`, "`math.pow(2, 64)` overflows INTEGER"},
+		{`math.pow(2, 63)`, "`math.pow(2, 63)` overflows INTEGER"},
+		{`math.pow(-3037000500, 3)`, "`math.pow(-3037000500, 3)` overflows INTEGER"},
+		{`math.lcm(9223372036854775807, 2)`, "`math.lcm(9223372036854775807, 2)` overflows INTEGER"},
+		{`math.lcm(-9223372036854775807 - 1, 1)`, "`math.lcm(-9223372036854775808, 1)` overflows INTEGER"},
+		{`math.abs(-9223372036854775807 - 1)`, "`math.abs(-9223372036854775808)` overflows INTEGER"},
+		{`math.gcd(-9223372036854775807 - 1, 0)`, "`math.gcd(-9223372036854775808, 0)` overflows INTEGER"},
+		{`math.floor(-9223372036854775807 - 1, -1)`, "`math.floor(-9223372036854775808, -1)` overflows INTEGER"},
+		{`math.sum([9223372036854775807, 1])`, "`math.sum` overflows INTEGER"},
+		{`math.sum([-9223372036854775807, -2])`, "`math.sum` overflows INTEGER"},
+	}
+
+	for _, tt := range tests {
+		testErrorObject(t, testEval(tt.input), tt.expected)
+	}
+}
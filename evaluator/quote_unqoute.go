@@ -7,12 +7,12 @@ import (
 	"monkeylang/token"
 )
 
-func quote(node ast.Node, env *object.Environment) object.Object {
-	node = evalUnquoteCalls(node, env)
+func (in *Interpreter) quote(node ast.Node, env *object.Environment) object.Object {
+	node = in.evalUnquoteCalls(node, env)
 	return &object.Quote{Node: node}
 }
 
-func evalUnquoteCalls(quoted ast.Node, env *object.Environment) ast.Node {
+func (in *Interpreter) evalUnquoteCalls(quoted ast.Node, env *object.Environment) ast.Node {
 	return ast.Modify(quoted, func(node ast.Node) ast.Node {
 		expr, ok := node.(*ast.CallExpression)
 		if !ok {
@@ -27,7 +27,7 @@ func evalUnquoteCalls(quoted ast.Node, env *object.Environment) ast.Node {
 			return node
 		}
 
-		return convertObjectToAstNode(Eval(expr.Arguments[0], env))
+		return convertObjectToAstNode(in.eval(expr.Arguments[0], env))
 	})
 }
 
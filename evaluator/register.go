@@ -0,0 +1,101 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkeylang/object"
+	"reflect"
+)
+
+var errorType = reflect.TypeFor[error]()
+
+// Register makes fn callable from Monkey programs as name, replacing any
+// builtin of that name. fn is either an object.BuiltinFunction or an
+// ordinary Go func with at most one result besides a trailing error.
+// Arguments are converted with object.ToGo and the result with
+// object.FromGo on each call; a failed conversion or a non-nil error
+// result becomes a Monkey error.
+func (in *Interpreter) Register(name string, fn any) error {
+	builtin, err := adaptFunc(name, fn)
+	if err != nil {
+		return err
+	}
+
+	in.builtins[name] = builtin
+	return nil
+}
+
+// adaptFunc wraps fn in a builtin that converts between Monkey and Go
+// values.
+func adaptFunc(name string, fn any) (*object.Builtin, error) {
+	switch fn := fn.(type) {
+	case object.BuiltinFunction:
+		return &object.Builtin{Fn: fn}, nil
+	case func(...object.Object) object.Object:
+		return &object.Builtin{Fn: fn}, nil
+	}
+
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return nil, fmt.Errorf("register %s: %T is not a func", name, fn)
+	}
+
+	t := v.Type()
+	returnsError := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	results := t.NumOut()
+	if returnsError {
+		results--
+	}
+	if results > 1 {
+		return nil, fmt.Errorf("register %s: too many results", name)
+	}
+
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if t.IsVariadic() && len(args) < t.NumIn()-1 {
+				return newError("wrong number of arguments. got=%d, want=%d or more",
+					len(args), t.NumIn()-1)
+			}
+			if !t.IsVariadic() && len(args) != t.NumIn() {
+				return newError("wrong number of arguments. got=%d, want=%d",
+					len(args), t.NumIn())
+			}
+
+			in := make([]reflect.Value, len(args))
+			for i, arg := range args {
+				val := reflect.New(paramType(t, i))
+				if err := object.ToGo(arg, val.Interface()); err != nil {
+					return newError("argument %d to `%s`: %s", i+1, name, err)
+				}
+				in[i] = val.Elem()
+			}
+
+			out := v.Call(in)
+
+			if returnsError {
+				if err := out[len(out)-1]; !err.IsNil() {
+					return newError("%s: %s", name, err.Interface().(error))
+				}
+			}
+			if results == 0 {
+				return NULL
+			}
+
+			result, err := object.FromGo(out[0].Interface())
+			if err != nil {
+				return newError("%s: %s", name, err)
+			}
+
+			return result
+		},
+	}, nil
+}
+
+// paramType returns the type of the i-th argument passed to a func of
+// type t, unpacking a variadic parameter.
+func paramType(t reflect.Type, i int) reflect.Type {
+	if t.IsVariadic() && i >= t.NumIn()-1 {
+		return t.In(t.NumIn() - 1).Elem()
+	}
+
+	return t.In(i)
+}
@@ -0,0 +1,109 @@
+package evaluator_test
+
+import (
+	"errors"
+	"monkeylang/evaluator"
+	"monkeylang/object"
+	"strings"
+	"testing"
+)
+
+type user struct {
+	Name string   `monkey:"name"`
+	Tags []string `monkey:"tags"`
+}
+
+func TestRegister(t *testing.T) {
+	interp := evaluator.New()
+
+	funcs := map[string]any{
+		"repeat": func(s string, n int64) (string, error) {
+			if n < 0 {
+				return "", errors.New("negative count")
+			}
+			return strings.Repeat(s, int(n)), nil
+		},
+		"sum": func(nums ...int) int {
+			total := 0
+			for _, n := range nums {
+				total += n
+			}
+			return total
+		},
+		"words":   strings.Fields,
+		"not":     func(b bool) bool { return !b },
+		"first":   func(objs []object.Object) object.Object { return objs[0] },
+		"fail":    func() error { return errors.New("boom") },
+		"noop":    func() {},
+		"raw":     object.BuiltinFunction(func(args ...object.Object) object.Object { return &object.Integer{Value: int64(len(args))} }),
+		"byte_of": func(b uint8) uint8 { return b },
+		"ratio":   func(f float64) float64 { return f },
+		"half":    func(n int) float64 { return float64(n) / 2 },
+		"user": func(u user) map[string]any {
+			return map[string]any{"greeting": "hi " + u.Name, "tags": u.Tags}
+		},
+	}
+	for name, fn := range funcs {
+		if err := interp.Register(name, fn); err != nil {
+			t.Fatalf("Register(%q) failed: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`repeat("ab", 3)`, `"ababab"`},
+		{`sum()`, `0`},
+		{`sum(1, 2, 3)`, `6`},
+		{`words(" a b  c ")`, `["a", "b", "c"]`},
+		{`not(false)`, `true`},
+		{`first([{"a": 1}, 2])`, `{"a": 1}`},
+		{`noop()`, `if (false) { 1 }`},
+		{`raw(1, 2)`, `2`},
+		{`byte_of(255)`, `255`},
+		{`user({"name": "Bo", "tags": ["a"]})`, `{"greeting": "hi Bo", "tags": ["a"]}`},
+	}
+
+	for _, tt := range tests {
+		testEqualObjectIn(t, interp, tt.input, tt.expected)
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{`repeat("a", -1)`, "repeat: negative count"},
+		{`fail()`, "fail: boom"},
+		{`repeat("a")`, "wrong number of arguments. got=1, want=2"},
+		{`repeat(1, 2)`, "argument 1 to `repeat`: cannot convert INTEGER to string"},
+		{`sum(1, "2")`, "argument 2 to `sum`: cannot convert STRING to int"},
+		{`byte_of(256)`, "argument 1 to `byte_of`: 256 overflows uint8"},
+		{`byte_of(-1)`, "argument 1 to `byte_of`: -1 overflows uint8"},
+		{`words([1])`, "argument 1 to `words`: cannot convert ARRAY to string"},
+		{`ratio(1)`, "argument 1 to `ratio`: unsupported type float64"},
+		{`half(3)`, "half: unsupported type float64"},
+		{`user({"name": 1})`, "argument 1 to `user`: Name: cannot convert INTEGER to string"},
+	}
+
+	for _, tt := range errorTests {
+		testErrorObject(t, testEvalIn(interp, tt.input), tt.expected)
+	}
+}
+
+func TestRegisterRejectsUnsupportedFuncs(t *testing.T) {
+	tests := []struct {
+		fn       any
+		expected string
+	}{
+		{42, "register f: int is not a func"},
+		{func() (int, int) { return 0, 0 }, "register f: too many results"},
+	}
+
+	for _, tt := range tests {
+		err := evaluator.New().Register("f", tt.fn)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("wrong error. got=%v, want=%q", err, tt.expected)
+		}
+	}
+}
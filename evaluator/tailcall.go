@@ -0,0 +1,86 @@
+package evaluator
+
+import (
+	"monkeylang/ast"
+	"monkeylang/object"
+)
+
+// tailCall is a call in tail position that has not been made yet.
+// applyFunction runs it in place of the call that returned it. It never
+// escapes a function body.
+type tailCall struct {
+	fn    *object.Function
+	args  []object.Object
+	frame object.Frame
+}
+
+func (tc *tailCall) Type() object.ObjectType { return "TAIL_CALL" }
+func (tc *tailCall) Inspect() string         { return "tail call" }
+
+// evalTail evaluates node, a part of a function body. tail reports
+// whether node's value becomes the value of the function. Calls in tail
+// position, and calls whose value is returned with `return`, come back as
+// a tailCall. Everything else is left to eval.
+func (in *Interpreter) evalTail(node ast.Node, env *object.Environment, tail bool) object.Object {
+	switch node := node.(type) {
+	case *ast.BlockStatement:
+		if err := in.step(); err != nil {
+			return err
+		}
+
+		var result object.Object
+		for i, stmt := range node.Statements {
+			result = in.evalTail(stmt, env, tail && i == len(node.Statements)-1)
+
+			switch result := result.(type) {
+			case *object.ReturnValue, *object.Error, *object.Exit:
+				return result
+			}
+		}
+
+		return result
+
+	case *ast.ExpressionStatement:
+		if err := in.step(); err != nil {
+			return err
+		}
+		return in.evalTail(node.Expression, env, tail)
+
+	case *ast.ReturnStatement:
+		if err := in.step(); err != nil {
+			return err
+		}
+
+		val := in.evalTail(node.ReturnValue, env, true)
+		if isError(val) {
+			return val
+		}
+		return &object.ReturnValue{Value: val}
+
+	case *ast.IfExpression:
+		if err := in.step(); err != nil {
+			return err
+		}
+
+		cond := in.eval(node.Condition, env)
+		if isError(cond) {
+			return cond
+		}
+
+		if isTruthy(cond) {
+			return in.evalTail(node.ThenBranch, env, tail)
+		}
+		if node.ElseBranch != nil {
+			return in.evalTail(node.ElseBranch, env, tail)
+		}
+		return NULL
+
+	case *ast.CallExpression:
+		if err := in.step(); err != nil {
+			return err
+		}
+		return in.evalCallExpression(node, env, tail)
+	}
+
+	return in.eval(node, env)
+}
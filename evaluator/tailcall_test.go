@@ -0,0 +1,45 @@
+package evaluator_test
+
+import (
+	"monkeylang/evaluator"
+	"testing"
+)
+
+func TestTailCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let loop = fn(n) { if (n == 0) { 0 } else { loop(n - 1) } }; loop(100000)`, `0`},
+		{`let sum = fn(n, acc) { if (n == 0) { return acc; } return sum(n - 1, acc + n); }; sum(100000, 0)`, `5000050000`},
+		{`let f = fn(n) { if (n > 0) { return f(n - 1); } "done" }; f(100000)`, `"done"`},
+		{`let even = fn(n) { if (n == 0) { true } else { odd(n - 1) } };
+		  let odd = fn(n) { if (n == 0) { false } else { even(n - 1) } };
+		  even(100001)`, `false`},
+		{`let f = fn(n) { if (n == 0) { len("abc") } else { f(n - 1) } }; f(1000)`, `3`},
+		{`let count = fn(n) { if (n == 0) { 0 } else { 1 + count(n - 1) } }; count(100)`, `100`},
+		{`let apply = fn(g, x) { g(x) }; apply(fn(x) { x * 2 }, 21)`, `42`},
+	}
+
+	for _, tt := range tests {
+		testEqualObject(t, tt.input, tt.expected)
+	}
+
+	quoted := testEval(`let f = fn() { quote(1 + 2) }; f()`)
+	if quoted.Inspect() != "QUOTE((1 + 2))" {
+		t.Errorf("quote in tail position wrong. got=%s", quoted.Inspect())
+	}
+}
+
+func TestTailCallsDoNotNest(t *testing.T) {
+	interp := evaluator.New(evaluator.WithLimits(evaluator.Limits{MaxCallDepth: 5}))
+
+	testEqualObjectIn(t, interp,
+		`let loop = fn(n) { if (n == 0) { "ok" } else { loop(n - 1) } }; loop(10000)`, `"ok"`)
+	testErrorObject(t, testEvalIn(interp,
+		`let count = fn(n) { if (n == 0) { 0 } else { 1 + count(n - 1) } }; count(10)`),
+		"maximum call depth of 5 exceeded")
+	testErrorObject(t, testEvalIn(interp,
+		`let f = fn(n) { if (n == 0) { 1 + true } else { f(n - 1) } }; f(10)`),
+		"type mismatch: INTEGER + BOOLEAN")
+}
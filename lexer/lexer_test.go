@@ -28,6 +28,8 @@ if (5 < 10) {
 [1, 2];
 { "foo": "bar" };
 macro(x, y) { x + y; };
+math.abs;
+"say \"hi\"\n\\";
 `
 
 	tests := []struct {
@@ -146,6 +148,13 @@ macro(x, y) { x + y; };
 		{token.RBRACE, "}"},
 		{token.SEMICOLON, ";"},
 
+		{token.IDENT, "math"},
+		{token.DOT, "."},
+		{token.IDENT, "abs"},
+		{token.SEMICOLON, ";"},
+		{token.STRING, "say \"hi\"\n\\"},
+		{token.SEMICOLON, ";"},
+
 		{token.EOF, ""},
 	}
 
@@ -167,3 +176,43 @@ macro(x, y) { x + y; };
 		}
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := `let x = 5;
+  puts("a b",
+x)`
+
+	tests := []struct {
+		expectedLiteral string
+		expectedPos     token.Position
+	}{
+		{"let", token.Position{Line: 1, Column: 1}},
+		{"x", token.Position{Line: 1, Column: 5}},
+		{"=", token.Position{Line: 1, Column: 7}},
+		{"5", token.Position{Line: 1, Column: 9}},
+		{";", token.Position{Line: 1, Column: 10}},
+		{"puts", token.Position{Line: 2, Column: 3}},
+		{"(", token.Position{Line: 2, Column: 7}},
+		{"a b", token.Position{Line: 2, Column: 8}},
+		{",", token.Position{Line: 2, Column: 13}},
+		{"x", token.Position{Line: 3, Column: 1}},
+		{")", token.Position{Line: 3, Column: 2}},
+		{"", token.Position{Line: 3, Column: 3}},
+	}
+
+	l := lexer.New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - wrong Literal. Expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Pos != tt.expectedPos {
+			t.Fatalf("tests[%d] - wrong Pos for %q. Expected=%s, got=%s",
+				i, tt.expectedLiteral, tt.expectedPos, tok.Pos)
+		}
+	}
+}
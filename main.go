@@ -1,13 +1,23 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"monkeylang/evaluator"
+	"monkeylang/object"
 	"monkeylang/repl"
+	"monkeylang/resolver"
 	"os"
 	"os/user"
 )
 
 func main() {
+	fsys := evaluator.WithFileSystem(evaluator.DirFS("."))
+
+	if len(os.Args) > 1 {
+		os.Exit(runFile(os.Args[1], fsys))
+	}
+
 	user, err := user.Current()
 	if err != nil {
 		panic(err)
@@ -18,5 +28,41 @@ func main() {
 		user.Username,
 	)
 	fmt.Println("Feel free to type in commands")
-	repl.Start(os.Stdin, os.Stdout)
+	repl.Start(os.Stdin, os.Stdout, fsys)
+}
+
+// runFile checks and evaluates the script at path and returns the process
+// exit code. Diagnostics are printed but do not stop the script: code the
+// resolver objects to may never run.
+func runFile(path string, opts ...evaluator.Option) int {
+	opts = append(opts, evaluator.WithDiagnostics(func(diagnostics []resolver.Diagnostic) {
+		for _, d := range diagnostics {
+			fmt.Fprintf(os.Stderr, "%s:%s\n", path, d)
+		}
+	}))
+	interpreter := evaluator.New(opts...)
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	result, err := interpreter.EvalString(string(src))
+
+	var runtimeErr *object.Error
+	if errors.As(err, &runtimeErr) {
+		fmt.Fprintln(os.Stderr, runtimeErr.Traceback())
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if exit, ok := result.(*object.Exit); ok {
+		return int(exit.Code)
+	}
+
+	return 0
 }
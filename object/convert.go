@@ -0,0 +1,477 @@
+package object
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var objectType = reflect.TypeFor[Object]()
+
+// FromGo converts a Go value to a Monkey object. Booleans, integers and
+// strings become their Monkey counterparts, slices and arrays become
+// arrays, maps and structs become hashes and nil pointers become null.
+// Struct fields are keyed by their name or by a `monkey:"name"` tag; the
+// tag "-" skips a field and the option "omitempty" skips zero values.
+// Objects are returned unchanged. Other types, such as floats, channels
+// and funcs, are reported as errors, as are values that contain
+// themselves.
+func FromGo(v any) (Object, error) {
+	if v == nil {
+		return NULL, nil
+	}
+
+	return fromGo(reflect.ValueOf(v), "", make(map[visit]bool))
+}
+
+// ToGo stores obj in the value target points to, converting it to the
+// target's type the way FromGo converts the other way. Hash keys missing
+// from a struct leave the field alone and extra keys are ignored. A target
+// of type any receives int64, string, bool, nil, []any or map[string]any.
+func ToGo(obj Object, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+
+	return toGo(obj, v.Elem(), "")
+}
+
+// conversionError reports a failure at path, the location of the failing
+// value within the value being converted.
+func conversionError(path, format string, a ...any) error {
+	msg := fmt.Sprintf(format, a...)
+	if path == "" {
+		return errors.New(msg)
+	}
+
+	return fmt.Errorf("%s: %s", strings.TrimPrefix(path, "."), msg)
+}
+
+// visit identifies a pointer, map or slice that fromGo is converting, so
+// that values containing themselves are reported instead of recursing
+// forever. Slices that share an array but differ in length are distinct.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// enter records v as being converted on the current path, or reports a
+// cycle if it already is. The returned func removes it again.
+func enter(v reflect.Value, path string, seen map[visit]bool) (func(), error) {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+
+	if seen[key] {
+		return nil, conversionError(path, "cycle through %s", v.Type())
+	}
+
+	seen[key] = true
+	return func() { delete(seen, key) }, nil
+}
+
+func fromGo(v reflect.Value, path string, seen map[visit]bool) (Object, error) {
+	if v.Type().Implements(objectType) {
+		if isNil(v) {
+			return NULL, nil
+		}
+		return v.Interface().(Object), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return TRUE, nil
+		}
+		return FALSE, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Integer{Value: v.Int()}, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, conversionError(path, "%d overflows INTEGER", v.Uint())
+		}
+		return &Integer{Value: int64(v.Uint())}, nil
+
+	case reflect.String:
+		return &String{Value: v.String()}, nil
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return NULL, nil
+		}
+		return fromGo(v.Elem(), path, seen)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return NULL, nil
+		}
+		leave, err := enter(v, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		return fromGo(v.Elem(), path, seen)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return NULL, nil
+			}
+			leave, err := enter(v, path, seen)
+			if err != nil {
+				return nil, err
+			}
+			defer leave()
+		}
+
+		elements := make([]Object, v.Len())
+		for i := range elements {
+			el, err := fromGo(v.Index(i), fmt.Sprintf("%s[%d]", path, i), seen)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = el
+		}
+		return &Array{Elements: elements}, nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			return NULL, nil
+		}
+		leave, err := enter(v, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		return mapFromGo(v, path, seen)
+
+	case reflect.Struct:
+		return structFromGo(v, path, seen)
+	}
+
+	return nil, conversionError(path, "unsupported type %s", v.Type())
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+// mapFromGo converts a map to a hash. Go maps are unordered, so the pairs
+// are sorted by key to keep the result deterministic.
+func mapFromGo(v reflect.Value, path string, seen map[visit]bool) (Object, error) {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, compareKeys)
+
+	hash := NewHash()
+	for _, k := range keys {
+		keyPath := fmt.Sprintf("%s[%v]", path, k)
+
+		key, err := fromGo(k, keyPath, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := fromGo(v.MapIndex(k), keyPath, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hash.Set(key, value) {
+			return nil, conversionError(keyPath, "unusable as hash key: %s", key.Type())
+		}
+	}
+
+	return hash, nil
+}
+
+func compareKeys(a, b reflect.Value) int {
+	for a.Kind() == reflect.Interface || a.Kind() == reflect.Pointer {
+		if a.IsNil() {
+			break
+		}
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface || b.Kind() == reflect.Pointer {
+		if b.IsNil() {
+			break
+		}
+		b = b.Elem()
+	}
+
+	switch {
+	case a.CanInt() && b.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint() && b.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String())
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func structFromGo(v reflect.Value, path string, seen map[visit]bool) (Object, error) {
+	hash := NewHash()
+
+	for _, field := range structFields(v.Type()) {
+		fv := v.FieldByIndex(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		value, err := fromGo(fv, path+"."+field.goName, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		hash.Set(&String{Value: field.name}, value)
+	}
+
+	return hash, nil
+}
+
+type structField struct {
+	name      string
+	goName    string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields lists the exported fields of t with the names they have in
+// Monkey hashes.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("monkey")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, structField{
+			name:      name,
+			goName:    f.Name,
+			index:     f.Index,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+
+	return fields
+}
+
+func toGo(obj Object, v reflect.Value, path string) error {
+	if v.Type() == objectType {
+		v.Set(reflect.ValueOf(&obj).Elem())
+		return nil
+	}
+
+	if v.Type().Implements(objectType) && reflect.TypeOf(obj).AssignableTo(v.Type()) {
+		v.Set(reflect.ValueOf(obj))
+		return nil
+	}
+
+	if obj.Type() == NULL_OBJ {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			v.SetZero()
+			return nil
+		}
+	}
+
+	mismatch := func() error {
+		return conversionError(path, "cannot convert %s to %s", obj.Type(), v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := obj.(*Boolean)
+		if !ok {
+			return mismatch()
+		}
+		v.SetBool(b.Value)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := obj.(*Integer)
+		if !ok {
+			return mismatch()
+		}
+		if v.OverflowInt(i.Value) {
+			return conversionError(path, "%d overflows %s", i.Value, v.Type())
+		}
+		v.SetInt(i.Value)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := obj.(*Integer)
+		if !ok {
+			return mismatch()
+		}
+		if i.Value < 0 || v.OverflowUint(uint64(i.Value)) {
+			return conversionError(path, "%d overflows %s", i.Value, v.Type())
+		}
+		v.SetUint(uint64(i.Value))
+
+	case reflect.String:
+		s, ok := obj.(*String)
+		if !ok {
+			return mismatch()
+		}
+		v.SetString(s.Value)
+
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := toGo(obj, elem.Elem(), path); err != nil {
+			return err
+		}
+		v.Set(elem)
+
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return conversionError(path, "unsupported type %s", v.Type())
+		}
+		native, err := toNative(obj, path)
+		if err != nil {
+			return err
+		}
+		if native == nil {
+			v.SetZero()
+		} else {
+			v.Set(reflect.ValueOf(native))
+		}
+
+	case reflect.Slice, reflect.Array:
+		arr, ok := obj.(*Array)
+		if !ok {
+			return mismatch()
+		}
+
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(arr.Elements), len(arr.Elements)))
+		} else if v.Len() != len(arr.Elements) {
+			return conversionError(path, "cannot convert ARRAY of length %d to %s",
+				len(arr.Elements), v.Type())
+		}
+
+		for i, el := range arr.Elements {
+			if err := toGo(el, v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		hash, ok := obj.(*Hash)
+		if !ok {
+			return mismatch()
+		}
+
+		m := reflect.MakeMapWithSize(v.Type(), hash.Len())
+		for _, pair := range hash.Pairs() {
+			keyPath := fmt.Sprintf("%s[%s]", path, pair.Key.Inspect())
+
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := toGo(pair.Key, key, keyPath); err != nil {
+				return err
+			}
+
+			value := reflect.New(v.Type().Elem()).Elem()
+			if err := toGo(pair.Value, value, keyPath); err != nil {
+				return err
+			}
+
+			m.SetMapIndex(key, value)
+		}
+		v.Set(m)
+
+	case reflect.Struct:
+		hash, ok := obj.(*Hash)
+		if !ok {
+			return mismatch()
+		}
+
+		for _, field := range structFields(v.Type()) {
+			value, ok := hash.Get(&String{Value: field.name})
+			if !ok {
+				continue
+			}
+
+			fv := v.FieldByIndex(field.index)
+			if err := toGo(value, fv, path+"."+field.goName); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return conversionError(path, "unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// toNative converts obj to the plain Go value stored in an `any` target.
+func toNative(obj Object, path string) (any, error) {
+	switch obj := obj.(type) {
+	case *Null:
+		return nil, nil
+	case *Boolean:
+		return obj.Value, nil
+	case *Integer:
+		return obj.Value, nil
+	case *String:
+		return obj.Value, nil
+
+	case *Array:
+		elements := make([]any, len(obj.Elements))
+		for i, el := range obj.Elements {
+			native, err := toNative(el, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = native
+		}
+		return elements, nil
+
+	case *Hash:
+		m := make(map[string]any, obj.Len())
+		for _, pair := range obj.Pairs() {
+			keyPath := fmt.Sprintf("%s[%s]", path, pair.Key.Inspect())
+
+			key, ok := pair.Key.(*String)
+			if !ok {
+				return nil, conversionError(keyPath, "cannot convert %s hash key to string",
+					pair.Key.Type())
+			}
+
+			native, err := toNative(pair.Value, keyPath)
+			if err != nil {
+				return nil, err
+			}
+			m[key.Value] = native
+		}
+		return m, nil
+	}
+
+	return nil, conversionError(path, "cannot convert %s to a Go value", obj.Type())
+}
@@ -0,0 +1,215 @@
+package object_test
+
+import (
+	"monkeylang/object"
+	"reflect"
+	"testing"
+)
+
+type address struct {
+	City string `monkey:"city"`
+	Zip  string `monkey:"zip,omitempty"`
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+type person struct {
+	Name    string   `monkey:"name"`
+	Age     int      `monkey:"age"`
+	Admin   bool     `monkey:"admin"`
+	Tags    []string `monkey:"tags"`
+	Home    *address `monkey:"home"`
+	Secret  string   `monkey:"-"`
+	Plain   uint8
+	private int
+}
+
+func TestFromGo(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected string
+	}{
+		{nil, "null"},
+		{42, "42"},
+		{uint16(7), "7"},
+		{true, "true"},
+		{"hi", "hi"},
+		{[]int{1, 2}, "[\n1,\n2,\n]"},
+		{[2]string{"a", "b"}, "[\na,\nb,\n]"},
+		{[]int(nil), "null"},
+		{map[string]int{"b": 2, "a": 1}, "{a: 1, b: 2}"},
+		{map[int]bool{3: true, 1: false}, "{1: false, 3: true}"},
+		{&address{City: "Oslo"}, "{city: Oslo}"},
+		{(*address)(nil), "null"},
+		{[]any{1, "x", nil}, "[\n1,\nx,\nnull,\n]"},
+		{&object.Integer{Value: 5}, "5"},
+		{
+			person{Name: "Ann", Age: 30, Tags: []string{"x"}, Home: &address{City: "Rome", Zip: "00100"}, Secret: "s", Plain: 1},
+			"{name: Ann, age: 30, admin: false, tags: [\nx,\n], home: {city: Rome, zip: 00100}, Plain: 1}",
+		},
+	}
+
+	for _, tt := range tests {
+		obj, err := object.FromGo(tt.input)
+		if err != nil {
+			t.Errorf("FromGo(%#v) failed: %v", tt.input, err)
+			continue
+		}
+		if obj.Inspect() != tt.expected {
+			t.Errorf("FromGo(%#v) wrong. got=%s, want=%s", tt.input, obj.Inspect(), tt.expected)
+		}
+	}
+
+	if obj, _ := object.FromGo(false); obj != object.FALSE {
+		t.Errorf("FromGo(false) is not FALSE. got=%#v", obj)
+	}
+}
+
+func TestFromGoErrors(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected string
+	}{
+		{1.5, "unsupported type float64"},
+		{make(chan int), "unsupported type chan int"},
+		{[]any{1, func() {}}, "[1]: unsupported type func()"},
+		{struct{ Ratio float32 }{}, "Ratio: unsupported type float32"},
+		{map[string][]float64{"a": {1}}, "[a][0]: unsupported type float64"},
+		{uint64(1 << 63), "9223372036854775808 overflows INTEGER"},
+	}
+
+	for _, tt := range tests {
+		_, err := object.FromGo(tt.input)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("FromGo(%#v) wrong error. got=%v, want=%q", tt.input, err, tt.expected)
+		}
+	}
+}
+
+func TestFromGoCycles(t *testing.T) {
+	n := &node{Value: 1}
+	n.Next = &node{Value: 2, Next: n}
+
+	m := map[string]any{}
+	m["self"] = m
+
+	s := []any{nil}
+	s[0] = s
+
+	tests := []struct {
+		input    any
+		expected string
+	}{
+		{n, "Next.Next: cycle through *object_test.node"},
+		{m, "[self]: cycle through map[string]interface {}"},
+		{s, "[0]: cycle through []interface {}"},
+	}
+
+	for _, tt := range tests {
+		_, err := object.FromGo(tt.input)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("FromGo of a cyclic %T wrong error. got=%v, want=%q",
+				tt.input, err, tt.expected)
+		}
+	}
+
+	// A value reachable twice without a cycle is converted twice.
+	shared := &node{Value: 3}
+	obj, err := object.FromGo([]*node{shared, shared})
+	if err != nil {
+		t.Fatalf("FromGo of shared values failed: %v", err)
+	}
+	if expected := "[\n{Value: 3, Next: null},\n{Value: 3, Next: null},\n]"; obj.Inspect() != expected {
+		t.Errorf("FromGo of shared values wrong. got=%q, want=%q", obj.Inspect(), expected)
+	}
+}
+
+func TestToGo(t *testing.T) {
+	home := object.NewHash()
+	home.Set(&object.String{Value: "city"}, &object.String{Value: "Rome"})
+
+	hash := object.NewHash()
+	hash.Set(&object.String{Value: "name"}, &object.String{Value: "Ann"})
+	hash.Set(&object.String{Value: "age"}, &object.Integer{Value: 30})
+	hash.Set(&object.String{Value: "admin"}, object.TRUE)
+	hash.Set(&object.String{Value: "tags"}, &object.Array{Elements: []object.Object{&object.String{Value: "x"}}})
+	hash.Set(&object.String{Value: "home"}, home)
+	hash.Set(&object.String{Value: "Secret"}, &object.String{Value: "s"})
+	hash.Set(&object.String{Value: "extra"}, object.NULL)
+
+	var p person
+	if err := object.ToGo(hash, &p); err != nil {
+		t.Fatalf("ToGo failed: %v", err)
+	}
+
+	expected := person{Name: "Ann", Age: 30, Admin: true, Tags: []string{"x"}, Home: &address{City: "Rome"}}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("ToGo wrong. got=%+v, want=%+v", p, expected)
+	}
+
+	var m map[string]int
+	counts := object.NewHash()
+	counts.Set(&object.String{Value: "a"}, &object.Integer{Value: 1})
+	if err := object.ToGo(counts, &m); err != nil || m["a"] != 1 || len(m) != 1 {
+		t.Errorf("ToGo map wrong. got=%v (%v)", m, err)
+	}
+
+	var native any
+	if err := object.ToGo(&object.Array{Elements: []object.Object{&object.Integer{Value: 1}, counts, object.NULL}}, &native); err != nil {
+		t.Fatalf("ToGo any failed: %v", err)
+	}
+	if !reflect.DeepEqual(native, []any{int64(1), map[string]any{"a": int64(1)}, nil}) {
+		t.Errorf("ToGo any wrong. got=%#v", native)
+	}
+
+	var obj object.Object
+	if err := object.ToGo(counts, &obj); err != nil || obj != counts {
+		t.Errorf("ToGo Object wrong. got=%v (%v)", obj, err)
+	}
+
+	var ptr *int
+	if err := object.ToGo(object.NULL, &ptr); err != nil || ptr != nil {
+		t.Errorf("ToGo null wrong. got=%v (%v)", ptr, err)
+	}
+
+	var fixed [2]int
+	pair := &object.Array{Elements: []object.Object{&object.Integer{Value: 1}, &object.Integer{Value: 2}}}
+	if err := object.ToGo(pair, &fixed); err != nil || fixed != [2]int{1, 2} {
+		t.Errorf("ToGo array wrong. got=%v (%v)", fixed, err)
+	}
+}
+
+func TestToGoErrors(t *testing.T) {
+	tags := object.NewHash()
+	tags.Set(&object.String{Value: "tags"}, &object.Array{Elements: []object.Object{&object.Integer{Value: 1}}})
+
+	intKeys := object.NewHash()
+	intKeys.Set(&object.Integer{Value: 1}, object.TRUE)
+
+	tests := []struct {
+		obj      object.Object
+		target   any
+		expected string
+	}{
+		{&object.String{Value: "a"}, new(int), "cannot convert STRING to int"},
+		{&object.Integer{Value: 300}, new(uint8), "300 overflows uint8"},
+		{&object.Integer{Value: -1}, new(uint), "-1 overflows uint"},
+		{object.NULL, new(string), "cannot convert NULL to string"},
+		{tags, new(person), "Tags[0]: cannot convert INTEGER to string"},
+		{intKeys, new(any), "[1]: cannot convert INTEGER hash key to string"},
+		{&object.Integer{Value: 1}, new(float64), "unsupported type float64"},
+		{&object.Array{}, new([1]int), "cannot convert ARRAY of length 0 to [1]int"},
+		{object.NULL, 5, "target must be a non-nil pointer, got int"},
+	}
+
+	for _, tt := range tests {
+		err := object.ToGo(tt.obj, tt.target)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("ToGo(%s, %T) wrong error. got=%v, want=%q",
+				tt.obj.Inspect(), tt.target, err, tt.expected)
+		}
+	}
+}
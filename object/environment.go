@@ -1,5 +1,7 @@
 package object
 
+import "slices"
+
 type Environment struct {
 	store map[string]Object
 	outer *Environment
@@ -29,3 +31,17 @@ func (e *Environment) Set(name string, val Object) Object {
 	e.store[name] = val
 	return val
 }
+
+// Names returns the names bound in e and its enclosing environments,
+// sorted.
+func (e *Environment) Names() []string {
+	var names []string
+	for env := e; env != nil; env = env.outer {
+		for name := range env.store {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
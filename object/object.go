@@ -2,9 +2,12 @@ package object
 
 import (
 	"bytes"
+	"cmp"
+	"encoding/binary"
 	"fmt"
 	"hash/fnv"
 	"monkeylang/ast"
+	"monkeylang/token"
 	"strings"
 )
 
@@ -24,6 +27,16 @@ const (
 	QUOTE_OBJ        = "QUOTE"
 	UNQUOTE_OBJ      = "UNQUOTE"
 	MACRO_OBJ        = "MACRO"
+	MODULE_OBJ       = "MODULE"
+	EXIT_OBJ         = "EXIT"
+)
+
+// NULL, TRUE and FALSE are the only instances of their types; the
+// evaluator compares them by identity.
+var (
+	NULL  = &Null{}
+	TRUE  = &Boolean{Value: true}
+	FALSE = &Boolean{Value: false}
 )
 
 type Object interface {
@@ -37,6 +50,14 @@ type Integer struct {
 
 func (o *Integer) Inspect() string  { return fmt.Sprintf("%d", o.Value) }
 func (o *Integer) Type() ObjectType { return INTEGER_OBJ }
+func (o *Integer) Compare(other Object) (int, bool) {
+	right, ok := other.(*Integer)
+	if !ok {
+		return 0, false
+	}
+
+	return cmp.Compare(o.Value, right.Value), true
+}
 
 type Boolean struct {
 	Value bool
@@ -57,14 +78,102 @@ type ReturnValue struct {
 func (o *ReturnValue) Inspect() string  { return o.Value.Inspect() }
 func (o *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 
+// ErrorKind tells the host why evaluation stopped. Ordinary runtime errors
+// have the zero kind, RUNTIME_ERROR.
+type ErrorKind string
+
+const (
+	RUNTIME_ERROR  ErrorKind = ""
+	CANCELED_ERROR ErrorKind = "CANCELED"
+	TIMEOUT_ERROR  ErrorKind = "TIMEOUT"
+
+	STEP_LIMIT_ERROR   ErrorKind = "STEP_LIMIT"
+	DEPTH_LIMIT_ERROR  ErrorKind = "DEPTH_LIMIT"
+	OBJECT_LIMIT_ERROR ErrorKind = "OBJECT_LIMIT"
+	MEMORY_LIMIT_ERROR ErrorKind = "MEMORY_LIMIT"
+)
+
 type Error struct {
 	Message string
+	Kind    ErrorKind
+	// Pos is where the error was raised, if known.
+	Pos token.Position
+	// Expected and Actual hold the Inspect output of the values compared
+	// by a failed assertion.
+	Expected string
+	Actual   string
+	// Stack lists the function calls the error unwound through,
+	// innermost first.
+	Stack []Frame
+}
+
+// Frame is one function call on the stack of an error.
+type Frame struct {
+	// Function is the name the function was called by; it is empty for
+	// anonymous functions.
+	Function string
+	// Pos is the call site. It is not valid for calls made by builtins
+	// or by the embedding program.
+	Pos token.Position
+}
+
+func (f Frame) String() string {
+	name := f.Function
+	if name == "" {
+		name = "<anonymous>"
+	}
+
+	if f.Pos.IsValid() {
+		return "in " + name + ", called at " + f.Pos.String()
+	}
+	return "in " + name
 }
 
-func (o *Error) Inspect() string  { return "Error: " + o.Message }
+func (o *Error) Inspect() string {
+	if o.Pos.IsValid() {
+		return "Error: " + o.Message + " at " + o.Pos.String()
+	}
+	return "Error: " + o.Message
+}
 func (o *Error) Type() ObjectType { return ERROR_OBJ }
 
+// Traceback renders the error followed by its stack, one frame per line.
+func (o *Error) Traceback() string {
+	var out strings.Builder
+
+	out.WriteString(o.Inspect())
+	for _, frame := range o.Stack {
+		out.WriteString("\n  ")
+		out.WriteString(frame.String())
+	}
+
+	return out.String()
+}
+
+// Error lets runtime errors be returned as Go errors by the embedding API.
+func (o *Error) Error() string {
+	if o.Pos.IsValid() {
+		return o.Pos.String() + ": " + o.Message
+	}
+	return o.Message
+}
+
+// Exit is produced by the `exit` builtin. Like an Error it unwinds
+// evaluation; the embedder decides what to do with the code.
+type Exit struct {
+	Code int64
+}
+
+func (o *Exit) Inspect() string  { return fmt.Sprintf("exit(%d)", o.Code) }
+func (o *Exit) Type() ObjectType { return EXIT_OBJ }
+
 type Function struct {
+	// Name is the name of the function literal, if it has one.
+	Name string
+	// SelfBound reports whether Name is bound inside the function's
+	// body, as it is for `fn name(...)` but not for names taken from
+	// `let`.
+	SelfBound  bool
 	Parameters []*ast.IdentifierExpression
 	Body       *ast.BlockStatement
 	Env        *Environment
@@ -79,6 +188,9 @@ func (o *Function) Inspect() string {
 	}
 
 	out.WriteString("fn")
+	if o.Name != "" {
+		out.WriteString(" " + o.Name)
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") {\n")
@@ -94,6 +206,14 @@ type String struct {
 
 func (o *String) Inspect() string  { return o.Value }
 func (o *String) Type() ObjectType { return STRING_OBJ }
+func (o *String) Compare(other Object) (int, bool) {
+	right, ok := other.(*String)
+	if !ok {
+		return 0, false
+	}
+
+	return strings.Compare(o.Value, right.Value), true
+}
 
 type BuiltinFunction func(args ...Object) Object
 
@@ -122,6 +242,32 @@ func (o *Array) Inspect() string {
 }
 func (o *Array) Type() ObjectType { return ARRAY_OBJ }
 
+// Compare orders arrays element by element; when one array is a prefix of
+// the other, the shorter one comes first.
+func (o *Array) Compare(other Object) (int, bool) {
+	right, ok := other.(*Array)
+	if !ok {
+		return 0, false
+	}
+
+	for i := range min(len(o.Elements), len(right.Elements)) {
+		left, ok := o.Elements[i].(Comparable)
+		if !ok {
+			return 0, false
+		}
+
+		result, ok := left.Compare(right.Elements[i])
+		if !ok {
+			return 0, false
+		}
+		if result != 0 {
+			return result, true
+		}
+	}
+
+	return cmp.Compare(len(o.Elements), len(right.Elements)), true
+}
+
 type HashKey struct {
 	Type  ObjectType
 	Value uint64
@@ -155,8 +301,16 @@ type HashPair struct {
 	Value Object
 }
 
+// Hash keeps its pairs in insertion order. The buckets index the pairs by
+// HashKey; distinct keys whose hash keys collide share a bucket and are
+// told apart with Equal.
 type Hash struct {
-	Pairs map[HashKey]HashPair
+	pairs   []HashPair
+	buckets map[HashKey][]int
+}
+
+func NewHash() *Hash {
+	return &Hash{buckets: make(map[HashKey][]int)}
 }
 
 func (o *Hash) Type() ObjectType { return HASH_OBJ }
@@ -164,7 +318,7 @@ func (h *Hash) Inspect() string {
 	var out bytes.Buffer
 
 	pairs := []string{}
-	for _, pair := range h.Pairs {
+	for _, pair := range h.pairs {
 		pairs = append(pairs,
 			fmt.Sprintf("%s: %s", pair.Key.Inspect(), pair.Value.Inspect()))
 	}
@@ -176,10 +330,153 @@ func (h *Hash) Inspect() string {
 	return out.String()
 }
 
+// Get returns the value stored under key. It reports false when the key is
+// missing or cannot be hashed.
+func (h *Hash) Get(key Object) (Object, bool) {
+	i, ok := h.lookup(key)
+	if !ok {
+		return nil, false
+	}
+
+	return h.pairs[i].Value, true
+}
+
+// Set stores value under key. A new key is appended after the existing
+// ones; an existing key keeps its position and gets the new value. Set
+// reports false when the key cannot be hashed.
+func (h *Hash) Set(key, value Object) bool {
+	hashKey, ok := HashKeyOf(key)
+	if !ok {
+		return false
+	}
+
+	if i, ok := h.lookup(key); ok {
+		h.pairs[i].Value = value
+		return true
+	}
+
+	if h.buckets == nil {
+		h.buckets = make(map[HashKey][]int)
+	}
+	h.buckets[hashKey] = append(h.buckets[hashKey], len(h.pairs))
+	h.pairs = append(h.pairs, HashPair{Key: key, Value: value})
+
+	return true
+}
+
+func (h *Hash) lookup(key Object) (int, bool) {
+	hashKey, ok := HashKeyOf(key)
+	if !ok {
+		return 0, false
+	}
+
+	for _, i := range h.buckets[hashKey] {
+		if Equal(h.pairs[i].Key, key) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (h *Hash) Len() int { return len(h.pairs) }
+
+// Pairs returns the pairs in insertion order. The slice is shared with the
+// hash and must not be modified.
+func (h *Hash) Pairs() []HashPair { return h.pairs }
+
+// HashKeyOf returns the hash key of obj. Besides Hashable objects it
+// accepts arrays whose elements are all hashable.
+func HashKeyOf(obj Object) (HashKey, bool) {
+	switch obj := obj.(type) {
+	case Hashable:
+		return obj.HashKey(), true
+
+	case *Array:
+		h := fnv.New64a()
+		for _, el := range obj.Elements {
+			key, ok := HashKeyOf(el)
+			if !ok {
+				return HashKey{}, false
+			}
+
+			h.Write([]byte(key.Type))
+			binary.Write(h, binary.LittleEndian, key.Value)
+		}
+
+		return HashKey{Type: obj.Type(), Value: h.Sum64()}, true
+
+	default:
+		return HashKey{}, false
+	}
+}
+
+// Equal reports whether a and b hold the same value. Arrays and hashes are
+// compared element by element; other objects are equal only to themselves.
+func Equal(a, b Object) bool {
+	switch a := a.(type) {
+	case *Integer:
+		b, ok := b.(*Integer)
+		return ok && a.Value == b.Value
+
+	case *Boolean:
+		b, ok := b.(*Boolean)
+		return ok && a.Value == b.Value
+
+	case *String:
+		b, ok := b.(*String)
+		return ok && a.Value == b.Value
+
+	case *Null:
+		_, ok := b.(*Null)
+		return ok
+
+	case *Array:
+		b, ok := b.(*Array)
+		if !ok || len(a.Elements) != len(b.Elements) {
+			return false
+		}
+
+		for i := range a.Elements {
+			if !Equal(a.Elements[i], b.Elements[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	case *Hash:
+		b, ok := b.(*Hash)
+		if !ok || a.Len() != b.Len() {
+			return false
+		}
+
+		for _, pair := range a.Pairs() {
+			value, ok := b.Get(pair.Key)
+			if !ok || !Equal(pair.Value, value) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return a == b
+	}
+}
+
 type Hashable interface {
 	HashKey() HashKey
 }
 
+// Comparable is implemented by objects with a natural ordering. Compare
+// returns a negative number, zero or a positive number when the receiver is
+// less than, equal to or greater than other, and false when the two objects
+// cannot be ordered against each other.
+type Comparable interface {
+	Compare(other Object) (int, bool)
+}
+
 type Quote struct {
 	Node ast.Node
 }
@@ -212,3 +509,12 @@ func (o *Macro) Inspect() string {
 	return out.String()
 }
 func (o *Macro) Type() ObjectType { return MACRO_OBJ }
+
+// Module is a named namespace of objects, such as the `math` builtins.
+type Module struct {
+	Name    string
+	Members map[string]Object
+}
+
+func (o *Module) Inspect() string  { return "module(" + o.Name + ")" }
+func (o *Module) Type() ObjectType { return MODULE_OBJ }
@@ -2,6 +2,7 @@ package object_test
 
 import (
 	"monkeylang/object"
+	"monkeylang/token"
 	"testing"
 )
 
@@ -21,3 +22,139 @@ func TestStringHashKey(t *testing.T) {
 		t.Errorf("strings with different content have same hash keys")
 	}
 }
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		left     object.Comparable
+		right    object.Object
+		expected int
+		ok       bool
+	}{
+		{&object.Integer{Value: 1}, &object.Integer{Value: 2}, -1, true},
+		{&object.Integer{Value: 2}, &object.Integer{Value: 2}, 0, true},
+		{&object.String{Value: "b"}, &object.String{Value: "a"}, 1, true},
+		{&object.String{Value: "a"}, &object.Integer{Value: 1}, 0, false},
+		{
+			&object.Array{Elements: []object.Object{&object.Integer{Value: 1}}},
+			&object.Array{Elements: []object.Object{
+				&object.Integer{Value: 1}, &object.Integer{Value: 0},
+			}},
+			-1, true,
+		},
+		{
+			&object.Array{Elements: []object.Object{&object.Boolean{Value: true}}},
+			&object.Array{Elements: []object.Object{&object.Boolean{Value: true}}},
+			0, false,
+		},
+	}
+
+	for _, tt := range tests {
+		result, ok := tt.left.Compare(tt.right)
+		if ok != tt.ok {
+			t.Errorf("Compare(%s, %s) ok wrong. got=%t, want=%t",
+				tt.left.(object.Object).Inspect(), tt.right.Inspect(), ok, tt.ok)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("Compare(%s, %s) wrong. got=%d, want=%d",
+				tt.left.(object.Object).Inspect(), tt.right.Inspect(), result, tt.expected)
+		}
+	}
+}
+
+type collidingKey struct{ name string }
+
+func (k *collidingKey) Type() object.ObjectType { return "COLLIDING" }
+func (k *collidingKey) Inspect() string         { return k.name }
+func (k *collidingKey) HashKey() object.HashKey {
+	return object.HashKey{Type: k.Type(), Value: 42}
+}
+
+func TestHashCollisions(t *testing.T) {
+	first := &collidingKey{name: "first"}
+	second := &collidingKey{name: "second"}
+
+	hash := object.NewHash()
+	hash.Set(first, &object.Integer{Value: 1})
+	hash.Set(second, &object.Integer{Value: 2})
+
+	if hash.Len() != 2 {
+		t.Fatalf("colliding keys overwrote each other. got len=%d", hash.Len())
+	}
+
+	for key, expected := range map[object.Object]int64{first: 1, second: 2} {
+		value, ok := hash.Get(key)
+		if !ok {
+			t.Errorf("no value for key %s", key.Inspect())
+			continue
+		}
+		if value.(*object.Integer).Value != expected {
+			t.Errorf("wrong value for key %s. got=%s, want=%d",
+				key.Inspect(), value.Inspect(), expected)
+		}
+	}
+}
+
+func TestArrayHashKey(t *testing.T) {
+	one := &object.Array{Elements: []object.Object{
+		&object.Integer{Value: 1}, &object.String{Value: "a"},
+	}}
+	same := &object.Array{Elements: []object.Object{
+		&object.Integer{Value: 1}, &object.String{Value: "a"},
+	}}
+	diff := &object.Array{Elements: []object.Object{
+		&object.String{Value: "a"}, &object.Integer{Value: 1},
+	}}
+
+	oneKey, _ := object.HashKeyOf(one)
+	sameKey, _ := object.HashKeyOf(same)
+	diffKey, _ := object.HashKeyOf(diff)
+
+	if oneKey != sameKey {
+		t.Errorf("arrays with same content have different hash keys")
+	}
+	if oneKey == diffKey {
+		t.Errorf("arrays with different content have same hash keys")
+	}
+
+	unhashable := &object.Array{Elements: []object.Object{&object.Hash{}}}
+	if _, ok := object.HashKeyOf(unhashable); ok {
+		t.Errorf("array with unhashable element reported as hashable")
+	}
+}
+
+func TestHashInsertionOrder(t *testing.T) {
+	hash := object.NewHash()
+	for _, key := range []string{"c", "a", "b"} {
+		hash.Set(&object.String{Value: key}, &object.Integer{Value: 1})
+	}
+	hash.Set(&object.String{Value: "a"}, &object.Integer{Value: 2})
+
+	expected := "{c: 1, a: 2, b: 1}"
+	for range 10 {
+		if hash.Inspect() != expected {
+			t.Fatalf("hash.Inspect() wrong. got=%q, want=%q",
+				hash.Inspect(), expected)
+		}
+	}
+}
+
+func TestErrorTraceback(t *testing.T) {
+	err := &object.Error{
+		Message: "boom",
+		Pos:     token.Position{Line: 3, Column: 5},
+		Stack: []object.Frame{
+			{Function: "inner", Pos: token.Position{Line: 7, Column: 1}},
+			{},
+		},
+	}
+
+	expected := "Error: boom at 3:5\n  in inner, called at 7:1\n  in <anonymous>"
+	if err.Traceback() != expected {
+		t.Errorf("wrong traceback. got=%q, want=%q", err.Traceback(), expected)
+	}
+
+	if err.Error() != "3:5: boom" {
+		t.Errorf("wrong Error(). got=%q", err.Error())
+	}
+}
@@ -36,6 +36,7 @@ var precedences = map[token.TokenType]int{
 	token.ASTERISK:     FACTOR,
 	token.LPAREN:       CALL,
 	token.LBRACKET:     INDEX,
+	token.DOT:          INDEX,
 }
 
 type Parser struct {
@@ -85,6 +86,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfixParseFn(token.ASTERISK, p.parseBinaryExpression)
 	p.registerInfixParseFn(token.LPAREN, p.parseCallExpression)
 	p.registerInfixParseFn(token.LBRACKET, p.parseIndexExpression)
+	p.registerInfixParseFn(token.DOT, p.parseMemberExpression)
 
 	return p
 }
@@ -152,6 +154,10 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 	p.nextToken()
 	stmt.Value = p.parseExpression(LOWEST)
 
+	if fn, ok := stmt.Value.(*ast.FunctionLiteral); ok && fn.Name == "" {
+		fn.Name = stmt.Name.Value
+	}
+
 	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
@@ -270,6 +276,12 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 func (p *Parser) parseFunctionLiteral() ast.Expression {
 	fn := &ast.FunctionLiteral{Token: p.curToken}
 
+	if p.peekTokenIs(token.IDENT) {
+		p.nextToken()
+		fn.Name = p.curToken.Literal
+		fn.SelfBound = true
+	}
+
 	if !p.matchNext(token.LPAREN) {
 		return nil
 	}
@@ -434,9 +446,22 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	return expr
 }
 
+// parseMemberExpression parses `left.name` as the index expression
+// `left["name"]`.
+func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
+	expr := &ast.IndexExpression{Token: p.curToken, Left: left}
+
+	if !p.matchNext(token.IDENT) {
+		return nil
+	}
+
+	expr.Index = &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+
+	return expr
+}
+
 func (p *Parser) parseHashLiteral() ast.Expression {
 	hash := &ast.HashLiteral{Token: p.curToken}
-	hash.Pairs = make(map[ast.Expression]ast.Expression)
 
 	for !p.peekTokenIs(token.RBRACE) {
 		p.nextToken()
@@ -447,7 +472,7 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
-		hash.Pairs[key] = value
+		hash.Pairs = append(hash.Pairs, ast.HashPair{Key: key, Value: value})
 
 		if !p.peekTokenIs(token.RBRACE) && !p.matchNext(token.COMMA) {
 			return nil
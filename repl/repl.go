@@ -2,45 +2,72 @@ package repl
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"monkeylang/evaluator"
-	"monkeylang/lexer"
 	"monkeylang/object"
-	"monkeylang/parser"
+	"monkeylang/resolver"
+	"os"
+	"os/signal"
+	"strings"
 )
 
 const PROMPT = ">>> "
 
-func Start(in io.Reader, out io.Writer) {
-	scanner := bufio.NewScanner(in)
-	env := object.NewEnvironment()
-	macroEnv := object.NewEnvironment()
+// Start runs the read-eval-print loop. The opts configure the interpreter;
+// its input and output are always the REPL's own.
+func Start(in io.Reader, out io.Writer, opts ...evaluator.Option) {
+	reader := bufio.NewReader(in)
+
+	opts = append(opts,
+		evaluator.WithStdin(reader),
+		evaluator.WithStdout(out),
+		evaluator.WithStderr(out),
+		// Diagnostics are only advice here: a later line may still
+		// define a name this one uses.
+		evaluator.WithDiagnostics(func(diagnostics []resolver.Diagnostic) {
+			for _, d := range diagnostics {
+				fmt.Fprintln(out, d)
+			}
+		}),
+	)
+	interpreter := evaluator.New(opts...)
 
 	for {
 		fmt.Fprint(out, PROMPT)
-		scanned := scanner.Scan()
-		if !scanned {
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
 			return
 		}
 
-		line := scanner.Text()
+		line = strings.TrimRight(line, "\r\n")
 		if line == "exit" {
 			return
 		}
 
-		l := lexer.New(line)
-		p := parser.New(l)
+		// Ctrl-C stops the current evaluation rather than the REPL.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		evaluated, err := interpreter.EvalContext(ctx, line)
+		stop()
 
-		program := p.ParseProgram()
-		if len(p.Errors) != 0 {
-			printParseErrors(out, p.Errors)
+		var parseErr *evaluator.ParseError
+		if errors.As(err, &parseErr) {
+			printParseErrors(out, parseErr.Errors)
 			continue
 		}
 
-		evaluator.DefineMacros(program, macroEnv)
-		expanded := evaluator.ExpandMacros(program, macroEnv)
-		evaluated := evaluator.Eval(expanded, env)
+		var runtimeErr *object.Error
+		if errors.As(err, &runtimeErr) {
+			io.WriteString(out, runtimeErr.Traceback())
+			io.WriteString(out, "\n")
+			continue
+		}
+
+		if _, ok := evaluated.(*object.Exit); ok {
+			return
+		}
 
 		if evaluated != nil {
 			io.WriteString(out, evaluated.Inspect())
@@ -0,0 +1,315 @@
+// Package resolver checks Monkey programs for mistakes that can be found
+// without running them: undefined and unused names, shadowing and
+// unreachable code.
+package resolver
+
+import (
+	"fmt"
+	"monkeylang/ast"
+	"monkeylang/token"
+	"slices"
+	"strings"
+)
+
+type Severity string
+
+const (
+	// ERROR marks code that fails when it runs.
+	ERROR Severity = "error"
+	// WARNING marks code that runs but is probably a mistake.
+	WARNING Severity = "warning"
+)
+
+// Diagnostic is a problem found in a program.
+type Diagnostic struct {
+	Pos      token.Position
+	Severity Severity
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+}
+
+// Globals lists the names a program can use without defining them.
+type Globals struct {
+	// Builtins are the builtin functions and modules.
+	Builtins []string
+	// Defined are the names already bound in the global environment, such
+	// as those defined by earlier REPL lines.
+	Defined []string
+}
+
+// Resolve checks program, which should have its macros expanded, and
+// returns its diagnostics sorted by position, without duplicates.
+//
+// Function bodies are checked once the scope they are defined in is
+// complete, since they run after it and may use names defined later. Top
+// level bindings are never reported as unused: the host and later REPL
+// lines can still use them. Names starting with an underscore are never
+// reported as unused either.
+func Resolve(program *ast.Program, globals Globals) []Diagnostic {
+	r := &resolver{}
+
+	builtins := newScope(nil)
+	for _, name := range globals.Builtins {
+		builtins.names[name] = &binding{kind: builtinBinding}
+	}
+
+	top := newScope(builtins)
+	top.top = true
+	for _, name := range globals.Defined {
+		top.names[name] = &binding{kind: globalBinding}
+	}
+
+	r.resolveStatements(program.Statements, top)
+	r.finish(top)
+
+	slices.SortStableFunc(r.diagnostics, func(a, b Diagnostic) int {
+		if a.Pos.Line != b.Pos.Line {
+			return a.Pos.Line - b.Pos.Line
+		}
+		return a.Pos.Column - b.Pos.Column
+	})
+
+	// Macros can expand one expression into several copies of it.
+	return slices.Compact(r.diagnostics)
+}
+
+type bindingKind int
+
+const (
+	builtinBinding bindingKind = iota
+	globalBinding
+	letBinding
+	paramBinding
+	// selfBinding is the name a named function is bound to inside its
+	// own body.
+	selfBinding
+)
+
+type binding struct {
+	kind bindingKind
+	pos  token.Position
+	used bool
+}
+
+func (b *binding) describe(name string) string {
+	switch b.kind {
+	case builtinBinding:
+		return "builtin " + name
+	case globalBinding:
+		return "global " + name
+	case paramBinding:
+		return fmt.Sprintf("parameter %s declared at %s", name, b.pos)
+	}
+
+	return fmt.Sprintf("%s declared at %s", name, b.pos)
+}
+
+// scope holds the names bound in one environment. As in the evaluator,
+// only programs and function calls get an environment; blocks do not.
+type scope struct {
+	outer *scope
+	names map[string]*binding
+	top   bool
+	// functions defined in the scope, checked once it is complete.
+	functions []ast.Expression
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{outer: outer, names: make(map[string]*binding)}
+}
+
+func (s *scope) lookup(name string) (*binding, bool) {
+	for ; s != nil; s = s.outer {
+		if b, ok := s.names[name]; ok {
+			return b, true
+		}
+	}
+
+	return nil, false
+}
+
+type resolver struct {
+	diagnostics []Diagnostic
+}
+
+func (r *resolver) report(pos token.Position, severity Severity, format string, a ...any) {
+	r.diagnostics = append(r.diagnostics, Diagnostic{
+		Pos:      pos,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+func (r *resolver) resolveStatements(stmts []ast.Statement, s *scope) {
+	for i, stmt := range stmts {
+		if i > 0 {
+			if _, ok := stmts[i-1].(*ast.ReturnStatement); ok {
+				r.report(stmt.Pos(), WARNING, "unreachable code after return")
+			}
+		}
+
+		r.resolveStatement(stmt, s)
+	}
+}
+
+func (r *resolver) resolveStatement(stmt ast.Statement, s *scope) {
+	switch stmt := stmt.(type) {
+	case *ast.LetStatement:
+		r.resolveExpression(stmt.Value, s)
+		r.declare(stmt.Name, letBinding, s)
+	case *ast.ReturnStatement:
+		r.resolveExpression(stmt.ReturnValue, s)
+	case *ast.ExpressionStatement:
+		r.resolveExpression(stmt.Expression, s)
+	case *ast.BlockStatement:
+		r.resolveStatements(stmt.Statements, s)
+	}
+}
+
+func (r *resolver) resolveExpression(expr ast.Expression, s *scope) {
+	switch expr := expr.(type) {
+	case *ast.IdentifierExpression:
+		b, ok := s.lookup(expr.Value)
+		if !ok {
+			r.report(expr.Pos(), ERROR, "identifier not found: %s", expr.Value)
+			return
+		}
+		b.used = true
+
+	case *ast.UnaryExpression:
+		r.resolveExpression(expr.Right, s)
+
+	case *ast.BinaryExpression:
+		r.resolveExpression(expr.Left, s)
+		r.resolveExpression(expr.Right, s)
+
+	case *ast.IfExpression:
+		r.resolveExpression(expr.Condition, s)
+		r.resolveStatement(expr.ThenBranch, s)
+		if expr.ElseBranch != nil {
+			r.resolveStatement(expr.ElseBranch, s)
+		}
+
+	case *ast.FunctionLiteral, *ast.MacroLiteral:
+		s.functions = append(s.functions, expr)
+
+	case *ast.CallExpression:
+		if ident, ok := expr.Function.(*ast.IdentifierExpression); ok && ident.Value == "quote" {
+			for _, arg := range expr.Arguments {
+				r.resolveUnquotes(arg, s)
+			}
+			return
+		}
+
+		r.resolveExpression(expr.Function, s)
+		for _, arg := range expr.Arguments {
+			r.resolveExpression(arg, s)
+		}
+
+	case *ast.ArrayLiteral:
+		for _, el := range expr.Elements {
+			r.resolveExpression(el, s)
+		}
+
+	case *ast.IndexExpression:
+		r.resolveExpression(expr.Left, s)
+		r.resolveExpression(expr.Index, s)
+
+	case *ast.HashLiteral:
+		for _, pair := range expr.Pairs {
+			r.resolveExpression(pair.Key, s)
+			r.resolveExpression(pair.Value, s)
+		}
+	}
+}
+
+// resolveUnquotes resolves the arguments of the `unquote` calls in node,
+// the argument of a `quote`. The rest of a quoted expression is data.
+func (r *resolver) resolveUnquotes(node ast.Node, s *scope) {
+	ast.Modify(node, func(node ast.Node) ast.Node {
+		call, ok := node.(*ast.CallExpression)
+		if !ok || call.Function.TokenLiteral() != "unquote" {
+			return node
+		}
+
+		for _, arg := range call.Arguments {
+			r.resolveExpression(arg, s)
+		}
+		return node
+	})
+}
+
+// declare binds ident in s, reporting when it shadows a binding of an
+// enclosing scope.
+func (r *resolver) declare(ident *ast.IdentifierExpression, kind bindingKind, s *scope) {
+	name := ident.Value
+
+	if prev, ok := s.names[name]; ok {
+		r.reportUnused(name, prev, s)
+	} else if outer, ok := s.outer.lookup(name); ok && outer.kind != selfBinding {
+		r.report(ident.Pos(), WARNING, "%s shadows %s", name, outer.describe(name))
+	}
+
+	s.names[name] = &binding{kind: kind, pos: ident.Pos()}
+}
+
+// finish checks the functions defined in s, now that all of its names are
+// known, and reports the bindings of s that were never used.
+func (r *resolver) finish(s *scope) {
+	for _, fn := range s.functions {
+		r.resolveFunction(fn, s)
+	}
+
+	names := make([]string, 0, len(s.names))
+	for name := range s.names {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		r.reportUnused(name, s.names[name], s)
+	}
+}
+
+func (r *resolver) resolveFunction(fn ast.Expression, outer *scope) {
+	s := newScope(outer)
+
+	var params []*ast.IdentifierExpression
+	var body *ast.BlockStatement
+
+	switch fn := fn.(type) {
+	case *ast.FunctionLiteral:
+		if fn.SelfBound {
+			s.names[fn.Name] = &binding{kind: selfBinding, pos: fn.Pos()}
+		}
+		params, body = fn.Parameters, fn.Body
+	case *ast.MacroLiteral:
+		params, body = fn.Parameters, fn.Body
+	}
+
+	for _, param := range params {
+		if prev, ok := s.names[param.Value]; ok && prev.kind == selfBinding {
+			delete(s.names, param.Value)
+		}
+		r.declare(param, paramBinding, s)
+	}
+
+	r.resolveStatements(body.Statements, s)
+	r.finish(s)
+}
+
+func (r *resolver) reportUnused(name string, b *binding, s *scope) {
+	if b.used || s.top || strings.HasPrefix(name, "_") {
+		return
+	}
+
+	switch b.kind {
+	case letBinding:
+		r.report(b.pos, WARNING, "%s declared and not used", name)
+	case paramBinding:
+		r.report(b.pos, WARNING, "parameter %s not used", name)
+	}
+}
@@ -0,0 +1,113 @@
+package resolver_test
+
+import (
+	"monkeylang/lexer"
+	"monkeylang/parser"
+	"monkeylang/resolver"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	globals := resolver.Globals{
+		Builtins: []string{"len", "puts", "map", "math"},
+		Defined:  []string{"config"},
+	}
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`let x = 1; puts(x + len("a") + math.abs(config))`, nil},
+		{`let x = 1; x + y`, []string{"1:16: error: identifier not found: y"}},
+		{`puts(x); let x = 1;`, []string{"1:6: error: identifier not found: x"}},
+		{`let x = x + 1;`, []string{"1:9: error: identifier not found: x"}},
+		{
+			// Function bodies see names defined later in their scope.
+			`let even = fn(n) { if (n == 0) { true } else { odd(n - 1) } };
+			 let odd = fn(n) { if (n == 0) { false } else { even(n - 1) } };`,
+			nil,
+		},
+		{
+			`let f = fn(a, b, _c) { let unused = 1; let used = a; used };`,
+			[]string{
+				"1:15: warning: parameter b not used",
+				"1:28: warning: unused declared and not used",
+			},
+		},
+		{
+			`let f = fn(x) { let helper = fn helper(n) { helper(n) }; x };`,
+			[]string{"1:21: warning: helper declared and not used"},
+		},
+		{
+			// Without `fn helper(...)`, the body calls whatever the let
+			// binding holds, which uses it.
+			`let f = fn(x) { let helper = fn(n) { helper(n) }; x };`,
+			nil,
+		},
+		{
+			`let f = fn(x) { let y = 1; let y = y + x; y };`,
+			nil,
+		},
+		{
+			`let f = fn(x) { let y = 1; let y = x; y };`,
+			[]string{"1:21: warning: y declared and not used"},
+		},
+		{
+			`let x = 1; let f = fn(x) { x }; let g = fn() { let len = 2; len };`,
+			[]string{
+				"1:23: warning: x shadows x declared at 1:5",
+				"1:52: warning: len shadows builtin len",
+			},
+		},
+		{
+			`let f = fn(n) { if (n > 0) { return n; puts(n); } return 0; 1 };`,
+			[]string{
+				"1:40: warning: unreachable code after return",
+				"1:61: warning: unreachable code after return",
+			},
+		},
+		{
+			`let x = 1; let f = fn() { fn(y) { x + y + z } };`,
+			[]string{"1:43: error: identifier not found: z"},
+		},
+		{
+			`map([1], fn fact(n) { if (n == 0) { 1 } else { n * fact(n - 1) } })`,
+			nil,
+		},
+		{
+			`let fact = 1; let f = fn fact(n) { fact };`,
+			[]string{"1:31: warning: parameter n not used"},
+		},
+		{
+			`let x = 1; quote(a + unquote(x + b))`,
+			[]string{"1:34: error: identifier not found: b"},
+		},
+		{
+			`let h = {"a": k}; h["a"]`,
+			[]string{"1:15: error: identifier not found: k"},
+		},
+		{
+			`let config = 2; let _skip = fn(_) { 1 };`,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		if len(p.Errors) != 0 {
+			t.Fatalf("parser errors for %q: %v", tt.input, p.Errors)
+		}
+
+		var got []string
+		for _, d := range resolver.Resolve(program, globals) {
+			got = append(got, d.String())
+		}
+
+		if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+			t.Errorf("wrong diagnostics for %q.\ngot:\n%s\nwant:\n%s",
+				tt.input, strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+		}
+	}
+}